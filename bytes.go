@@ -0,0 +1,58 @@
+package gyml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GetFromBytes parses yaml data and works as GetValue on its root node, useful for one-shot lookups,
+// parse the data once with yaml.Unmarshal when more values are needed
+// Examples:
+// GetFromBytes[int](data, "servers", "server1", "port") - port of server1 in data
+func GetFromBytes[DataType any](data []byte, keys ...string) (*DataType, error) {
+	root, err := parseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return GetValue[DataType](root, keys...)
+}
+
+// SetInBytes parses yaml data, works as SetValue on its root node and returns modified yaml,
+// comments and styles of the rest of the document are kept
+// Examples:
+// SetInBytes(data, 9101, "servers", "server1", "port") - data with changed port of server1
+func SetInBytes[DataType any](data []byte, value DataType, keys ...string) ([]byte, error) {
+	root, err := parseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SetValue(root, value, keys...); err != nil {
+		return nil, err
+	}
+	return Marshal(root)
+}
+
+// DeleteInBytes parses yaml data, works as DeleteValue on its root node and returns modified yaml
+// Examples:
+// DeleteInBytes(data, "servers", "server2") - data without server2
+func DeleteInBytes(data []byte, keys ...string) ([]byte, error) {
+	root, err := parseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := DeleteValue(root, keys...); err != nil {
+		return nil, err
+	}
+	return Marshal(root)
+}
+
+func parseBytes(data []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("cannot parse yaml: %w", err)
+	}
+	return &root, nil
+}
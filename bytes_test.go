@@ -0,0 +1,51 @@
+package gyml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFromBytes(t *testing.T) {
+	port, err := GetFromBytes[int]([]byte(testYAML), "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9001, *port)
+
+	names, err := GetFromBytes[[]string]([]byte(testYAML), "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "second_client"}, *names)
+
+	_, err = GetFromBytes[int]([]byte(testYAML), "servers", "server3", "port")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = GetFromBytes[int]([]byte("a: [1"), "a")
+	require.ErrorContains(t, err, "cannot parse yaml")
+}
+
+func TestSetInBytes(t *testing.T) {
+	out, err := SetInBytes([]byte("# servers\nservers:\n    server1:\n        port: 9001 # default\n"), 9101, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, "# servers\nservers:\n    server1:\n        port: 9101 # default\n", string(out))
+
+	out, err = SetInBytes([]byte(""), "localhost", "host")
+	require.NoError(t, err)
+	require.Equal(t, "host: localhost\n", string(out))
+
+	_, err = SetInBytes([]byte(testYAML), 1, "ints", "[5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	_, err = SetInBytes([]byte("a: [1"), 1, "a")
+	require.ErrorContains(t, err, "cannot parse yaml")
+}
+
+func TestDeleteInBytes(t *testing.T) {
+	out, err := DeleteInBytes([]byte("servers:\n    server1: {}\n    server2: {} # backup\n"), "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, "servers:\n    server2: {} # backup\n", string(out))
+
+	_, err = DeleteInBytes([]byte(testYAML), "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = DeleteInBytes([]byte("a: [1"), "a")
+	require.ErrorContains(t, err, "cannot parse yaml")
+}
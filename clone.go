@@ -0,0 +1,224 @@
+package gyml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CopyValue copies subtree on srcKeys path to dstKeys path, the copy does not share any node with the source
+// Missing part of the dstKeys path is created in the same way as SetValue does
+// Anchors of the copy are renamed by numeric suffix (e.g. base_2) when the document already contains them,
+// aliases inside the copy follow the renamed anchors, aliases to anchors outside of the subtree are kept
+// Examples:
+// CopyValue(&root, []string{"servers", "server1"}, []string{"servers", "server3"}) - clone server1 configuration as server3
+func CopyValue(root *yaml.Node, srcKeys []string, dstKeys []string) error {
+	if len(dstKeys) == 0 {
+		return ErrInvalidKeysList
+	}
+
+	node, err := resolveNode(root, srcKeys...)
+	if err != nil {
+		return err
+	}
+
+	clone, _ := cloneSubtree(node)
+	renameAnchors(root, clone)
+
+	_, err = setNode(root, clone, dstKeys...)
+	return err
+}
+
+// MoveValue moves subtree on srcKeys path to dstKeys path
+// The subtree is copied as CopyValue does and then the original node is deleted as DeleteValue does,
+// so parents which became empty are removed as well, e.g. [-1] -> [] moves the last item to the end
+// Returns ErrCircularMove when one path is prefix of the other one
+// Examples:
+// MoveValue(&root, []string{"servers"}, []string{"hosts"}) - rename top level servers section to hosts
+func MoveValue(root *yaml.Node, srcKeys []string, dstKeys []string) error {
+	if len(srcKeys) == 0 || len(dstKeys) == 0 {
+		return ErrInvalidKeysList
+	}
+
+	if isPathPrefix(srcKeys, dstKeys) || isPathPrefix(dstKeys, srcKeys) {
+		return fmt.Errorf("%w: %s -> %s", ErrCircularMove, strings.Join(srcKeys, "."), strings.Join(dstKeys, "."))
+	}
+
+	node, err := resolveWritableNode(root, srcKeys...)
+	if err != nil {
+		return err
+	}
+
+	// the original is captured before the copy is inserted, insertion can shift relative indexes like [-1]
+	parent, err := resolveNode(root, srcKeys[:len(srcKeys)-1]...)
+	if err != nil {
+		return err
+	}
+	original := childNode(parent, srcKeys[len(srcKeys)-1])
+
+	clone, clones := cloneSubtree(node)
+	if _, err := setNode(root, clone, dstKeys...); err != nil {
+		return err
+	}
+
+	removeNode(root, original)
+
+	// aliases from the rest of the document follow moved anchors
+	visitNodes(root, func(node *yaml.Node) {
+		if moved, ok := clones[node.Alias]; ok && node.Kind == yaml.AliasNode {
+			node.Alias = moved
+		}
+	})
+	return nil
+}
+
+// childNode returns child of mapping or sequence node for the key as it is stored in Content, aliases are not followed
+func childNode(parent *yaml.Node, key string) *yaml.Node {
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(parent.Content); i += 2 {
+			if defaultNavigator.matchKey(parent.Content[i], key) {
+				return parent.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		if index, err := parseValidIndex(key, parent); err == nil {
+			return parent.Content[index]
+		}
+	}
+	return nil
+}
+
+// removeNode removes target from the tree found by identity, mappings and sequences which became empty
+// by the removal are removed as well unless they are anchored, returns whether target was found
+func removeNode(node *yaml.Node, target *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return len(node.Content) > 0 && removeNode(node.Content[0], target)
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if node.Content[i] == target {
+				node.Content = slices.Delete(node.Content, i-1, i+1)
+				return true
+			}
+			if removeNode(node.Content[i], target) {
+				if isPrunable(node.Content[i]) {
+					node.Content = slices.Delete(node.Content, i-1, i+1)
+				}
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if item == target {
+				node.Content = slices.Delete(node.Content, i, i+1)
+				return true
+			}
+			if removeNode(item, target) {
+				if isPrunable(item) {
+					node.Content = slices.Delete(node.Content, i, i+1)
+				}
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cloneSubtree works as CloneNode, but aliases pointing outside of the subtree keep pointing to the original nodes
+// Returns the clone and map of original nodes to their clones
+func cloneSubtree(node *yaml.Node) (*yaml.Node, map[*yaml.Node]*yaml.Node) {
+	inside := map[*yaml.Node]bool{}
+	visitNodes(node, func(n *yaml.Node) {
+		inside[n] = true
+	})
+
+	clones := map[*yaml.Node]*yaml.Node{}
+	visitNodes(node, func(n *yaml.Node) {
+		if n.Alias != nil && !inside[n.Alias] {
+			clones[n.Alias] = n.Alias
+		}
+	})
+
+	clone := cloneNode(node, clones)
+	for original, cloned := range clones {
+		if original == cloned {
+			delete(clones, original)
+		}
+	}
+	return clone, clones
+}
+
+// renameAnchors renames anchors of subtree which are already used in the document, aliases of the subtree
+// pointing to renamed anchors are updated
+func renameAnchors(root *yaml.Node, subtree *yaml.Node) {
+	used := map[string]bool{}
+	visitNodes(root, func(node *yaml.Node) {
+		if node.Anchor != "" {
+			used[node.Anchor] = true
+		}
+	})
+
+	renamed := map[*yaml.Node]bool{}
+	visitNodes(subtree, func(node *yaml.Node) {
+		if node.Anchor == "" || !used[node.Anchor] {
+			return
+		}
+		name := node.Anchor
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s_%d", node.Anchor, i)
+		}
+		node.Anchor = name
+		used[name] = true
+		renamed[node] = true
+	})
+
+	visitNodes(subtree, func(node *yaml.Node) {
+		if node.Kind == yaml.AliasNode && renamed[node.Alias] {
+			node.Value = node.Alias.Anchor
+		}
+	})
+}
+
+// visitNodes calls fn for node and all nodes of its Content recursively, aliases are not followed
+func visitNodes(node *yaml.Node, fn func(node *yaml.Node)) {
+	fn(node)
+	for _, child := range node.Content {
+		visitNodes(child, fn)
+	}
+}
+
+func isPathPrefix(prefix []string, keys []string) bool {
+	return len(prefix) <= len(keys) && slices.Equal(prefix, keys[:len(prefix)])
+}
+
+// CloneNode returns deep copy of node, Content slices are copied recursively so both trees can be modified independently
+// Alias of the copy points to the copy of anchored node, node shared on several places is shared in the copy as well
+func CloneNode(node *yaml.Node) *yaml.Node {
+	return cloneNode(node, map[*yaml.Node]*yaml.Node{})
+}
+
+func cloneNode(node *yaml.Node, clones map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if clone, ok := clones[node]; ok {
+		return clone
+	}
+
+	clone := &yaml.Node{}
+	*clone = *node
+	clones[node] = clone
+
+	if node.Content != nil {
+		clone.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			clone.Content[i] = cloneNode(child, clones)
+		}
+	}
+	clone.Alias = cloneNode(node.Alias, clones)
+	return clone
+}
@@ -0,0 +1,287 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyValue(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = CopyValue(&root, []string{"servers", "server1"}, []string{"servers", "server3"})
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "servers", "server3", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	err = SetValue(&root, "server3.local", "servers", "server3", "host")
+	require.NoError(t, err)
+
+	host, err = GetValue[string](&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	err = CopyValue(&root, []string{"clients", "[0]"}, []string{"clients", "[]"})
+	require.NoError(t, err)
+
+	err = SetValue(&root, "third_client", "clients", "[-1]", "name")
+	require.NoError(t, err)
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "second_client", "third_client"}, *names)
+
+	err = CopyValue(&root, []string{"ints"}, []string{"backup", "ints"})
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "backup", "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30}, *ints)
+
+	err = CopyValue(&root, []string{"unknown"}, []string{"backup", "unknown"})
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	err = CopyValue(&root, []string{"ints"}, []string{})
+	require.Equal(t, ErrInvalidKeysList, err)
+
+	err = CopyValue(nil, []string{"ints"}, []string{"backup"})
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestMoveValue(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = MoveValue(&root, []string{"servers"}, []string{"hosts"})
+	require.NoError(t, err)
+
+	require.False(t, Exists(&root, "servers"))
+
+	host, err := GetValue[string](&root, "hosts", "server2", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server2.local", *host)
+
+	err = MoveValue(&root, []string{"hosts", "server1", "port"}, []string{"hosts", "server2", "backup_port"})
+	require.NoError(t, err)
+
+	port, err := GetValue[int](&root, "hosts", "server2", "backup_port")
+	require.NoError(t, err)
+	require.Equal(t, 9001, *port)
+
+	err = MoveValue(&root, []string{"hosts", "server1", "host"}, []string{"hosts", "server3", "host"})
+	require.NoError(t, err)
+
+	// server1 became empty after move and was removed
+	keys, err := Keys(&root, "hosts")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server2", "server3"}, keys)
+
+	err = MoveValue(&root, []string{"hosts"}, []string{"hosts", "nested"})
+	require.ErrorIs(t, err, ErrCircularMove)
+
+	err = MoveValue(&root, []string{"hosts", "server2"}, []string{"hosts"})
+	require.ErrorIs(t, err, ErrCircularMove)
+
+	err = MoveValue(&root, []string{"unknown"}, []string{"other"})
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.False(t, Exists(&root, "other"))
+
+	err = MoveValue(&root, []string{}, []string{"other"})
+	require.Equal(t, ErrInvalidKeysList, err)
+}
+
+func TestMoveValueRelativeIndex(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("list: [a, b, c]\nother: [x]\n"), &root)
+	require.NoError(t, err)
+
+	last, err := GetNode(&root, "list", "[-1]")
+	require.NoError(t, err)
+
+	// the original is removed, not the copy appended to the end
+	err = MoveValue(&root, []string{"list", "[-1]"}, []string{"list", "[]"})
+	require.NoError(t, err)
+
+	list, err := GetValue[[]string](&root, "list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, *list)
+
+	moved, err := GetNode(&root, "list", "[-1]")
+	require.NoError(t, err)
+	require.NotSame(t, last, moved)
+
+	err = MoveValue(&root, []string{"list", "[-2]"}, []string{"list", "[]"})
+	require.NoError(t, err)
+
+	list, err = GetValue[[]string](&root, "list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c", "b"}, *list)
+
+	err = MoveValue(&root, []string{"other", "[-1]"}, []string{"list", "[]"})
+	require.NoError(t, err)
+
+	list, err = GetValue[[]string](&root, "list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c", "b", "x"}, *list)
+	require.False(t, Exists(&root, "other"))
+}
+
+const anchorYAML = `
+base: &base
+  host: base.local
+  port: 9000
+servers:
+  server1: *base
+`
+
+const nestedAnchorYAML = `
+defaults:
+  base: &base
+    host: base.local
+  primary: *base
+  timeout: &timeout 30
+servers:
+  server1:
+    timeout: *timeout
+`
+
+func TestCopyValueAnchors(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(nestedAnchorYAML), &root)
+	require.NoError(t, err)
+
+	err = CopyValue(&root, []string{"defaults"}, []string{"fallback"})
+	require.NoError(t, err)
+
+	err = CopyValue(&root, []string{"defaults"}, []string{"other"})
+	require.NoError(t, err)
+
+	err = CopyValue(&root, []string{"servers", "server1"}, []string{"servers", "server2"})
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, `defaults:
+  base: &base
+    host: base.local
+  primary: *base
+  timeout: &timeout 30
+servers:
+  server1:
+    timeout: *timeout
+  server2:
+    timeout: *timeout
+fallback:
+  base: &base_2
+    host: base.local
+  primary: *base_2
+  timeout: &timeout_2 30
+other:
+  base: &base_3
+    host: base.local
+  primary: *base_3
+  timeout: &timeout_3 30
+`, string(out))
+
+	// output stays valid yaml with the same content
+	var reparsed yaml.Node
+	err = yaml.Unmarshal(out, &reparsed)
+	require.NoError(t, err)
+
+	changes, err := Diff(&root, &reparsed)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// copied alias points to copied anchor, alias outside of copied subtree points to the original anchor
+	err = SetValue(&root, "fallback.local", "fallback", "base", "host")
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "fallback", "primary", "host")
+	require.NoError(t, err)
+	require.Equal(t, "fallback.local", *host)
+
+	host, err = GetValue[string](&root, "defaults", "primary", "host")
+	require.NoError(t, err)
+	require.Equal(t, "base.local", *host)
+
+	_, server2, err := getEntry(&root, "servers", "server2", "timeout")
+	require.NoError(t, err)
+
+	_, timeout, err := getEntry(&root, "defaults", "timeout")
+	require.NoError(t, err)
+	require.Same(t, timeout, server2.Alias)
+}
+
+func TestMoveValueAnchors(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(anchorYAML), &root)
+	require.NoError(t, err)
+
+	err = MoveValue(&root, []string{"base"}, []string{"defaults", "base"})
+	require.NoError(t, err)
+
+	// moved anchor is not renamed
+	node, err := resolveNode(&root, "defaults", "base")
+	require.NoError(t, err)
+	require.Equal(t, "base", node.Anchor)
+
+	// alias outside of moved subtree follows the moved anchor
+	err = SetValue(&root, "moved.local", "defaults", "base", "host")
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "moved.local", *host)
+}
+
+func TestCloneNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(anchorYAML), &root)
+	require.NoError(t, err)
+
+	clone := CloneNode(&root)
+	require.Equal(t, &root, clone)
+
+	err = SetValue(clone, "clone.local", "base", "host")
+	require.NoError(t, err)
+
+	err = SetValue(clone, 1, "added", "[]")
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "base", "host")
+	require.NoError(t, err)
+	require.Equal(t, "base.local", *host)
+	require.False(t, Exists(&root, "added"))
+
+	// alias of the clone points to cloned anchor node
+	_, alias, err := getEntry(clone, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, yaml.AliasNode, alias.Kind)
+
+	anchor, err := resolveNode(clone, "base")
+	require.NoError(t, err)
+	require.Same(t, anchor, alias.Alias)
+
+	// appending to cloned content does not affect original content
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Content: make([]*yaml.Node, 1, 4)}
+	seq.Content[0] = &yaml.Node{Kind: yaml.ScalarNode, Value: "1"}
+	seqClone := CloneNode(seq)
+	seqClone.Content = append(seqClone.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "2"})
+	seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "3"})
+	require.Equal(t, "2", seqClone.Content[1].Value)
+
+	require.Nil(t, CloneNode(nil))
+}
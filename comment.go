@@ -0,0 +1,76 @@
+package gyml
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// CommentPosition selects which comment of the node is accessed
+type CommentPosition int
+
+const (
+	// comment on lines preceding the node
+	HeadComment CommentPosition = iota
+	// comment at the end of the node line
+	LineComment
+	// comment on lines following the node
+	FootComment
+)
+
+// SetComment sets comment of the node on the path, "#" prefix is added on serialization when missing
+// Head and foot comments of mapping entries are stored on the key node as yaml.v3 does when parsing,
+// line comment is stored on the key node only when the value is not a scalar (e.g. "servers: # comment")
+// Examples:
+// SetComment(&root, "managed by tool", HeadComment, "servers", "server1") - add "# managed by tool" line above server1
+// SetComment(&root, "", LineComment, "servers", "server1", "port") - remove line comment of server1 port
+func SetComment(root *yaml.Node, comment string, pos CommentPosition, keys ...string) error {
+	if err := checkWritablePath(keys); err != nil {
+		return err
+	}
+
+	node, err := commentNode(root, pos, keys...)
+	if err != nil {
+		return err
+	}
+
+	switch pos {
+	case HeadComment:
+		node.HeadComment = comment
+	case LineComment:
+		node.LineComment = comment
+	case FootComment:
+		node.FootComment = comment
+	}
+	return nil
+}
+
+// GetComment returns comment of the node on the path, empty string is returned when the comment is not set
+// Comments are located in the same way as SetComment stores them, parsed comments keep their "#" prefix
+// Examples:
+// GetComment(&root, HeadComment, "servers", "server1") - "# managed by tool"
+func GetComment(root *yaml.Node, pos CommentPosition, keys ...string) (string, error) {
+	node, err := commentNode(root, pos, keys...)
+	if err != nil {
+		return "", err
+	}
+
+	switch pos {
+	case LineComment:
+		return node.LineComment, nil
+	case FootComment:
+		return node.FootComment, nil
+	}
+	return node.HeadComment, nil
+}
+
+// commentNode returns node which holds comment on pos position for the path
+func commentNode(root *yaml.Node, pos CommentPosition, keys ...string) (*yaml.Node, error) {
+	keyNode, valueNode, err := getEntry(root, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyNode == nil || (pos == LineComment && valueNode.Kind == yaml.ScalarNode) {
+		return valueNode, nil
+	}
+	return keyNode, nil
+}
@@ -0,0 +1,107 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetComment(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetComment(&root, "managed by tool", HeadComment, "servers", "server1")
+	require.NoError(t, err)
+
+	err = SetComment(&root, "default port", LineComment, "servers", "server1", "port")
+	require.NoError(t, err)
+
+	err = SetComment(&root, "all servers", LineComment, "servers")
+	require.NoError(t, err)
+
+	err = SetComment(&root, "first", LineComment, "ints", "[0]")
+	require.NoError(t, err)
+
+	err = SetComment(&root, "end of ints", FootComment, "ints")
+	require.NoError(t, err)
+
+	err = SetComment(&root, "unknown", HeadComment, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	err = SetComment(nil, "unknown", HeadComment, "servers")
+	require.Equal(t, ErrRootNodeNotSet, err)
+
+	out, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "servers: # all servers\n")
+	require.Contains(t, string(out), "    # managed by tool\n    server1:\n")
+	require.Contains(t, string(out), "port: 9001 # default port\n")
+	require.Contains(t, string(out), "- 10 # first\n")
+	require.Contains(t, string(out), "# end of ints\n")
+}
+
+const commentedYAML = `
+# servers section
+servers: # all servers
+  # managed by tool
+  server1:
+    host: server1.local # primary
+    # foot of server1
+list:
+  # first item
+  - 1 # one
+`
+
+func TestGetComment(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(commentedYAML), &root)
+	require.NoError(t, err)
+
+	comment, err := GetComment(&root, HeadComment, "servers")
+	require.NoError(t, err)
+	require.Equal(t, "# servers section", comment)
+
+	comment, err = GetComment(&root, LineComment, "servers")
+	require.NoError(t, err)
+	require.Equal(t, "# all servers", comment)
+
+	comment, err = GetComment(&root, HeadComment, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, "# managed by tool", comment)
+
+	comment, err = GetComment(&root, LineComment, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "# primary", comment)
+
+	comment, err = GetComment(&root, FootComment, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "# foot of server1", comment)
+
+	comment, err = GetComment(&root, HeadComment, "list", "[0]")
+	require.NoError(t, err)
+	require.Equal(t, "# first item", comment)
+
+	comment, err = GetComment(&root, LineComment, "list", "[-1]")
+	require.NoError(t, err)
+	require.Equal(t, "# one", comment)
+
+	comment, err = GetComment(&root, FootComment, "list")
+	require.NoError(t, err)
+	require.Equal(t, "", comment)
+
+	comment, err = GetComment(&root, HeadComment, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, "", comment)
+
+	err = SetComment(&root, "new comment", HeadComment, "list")
+	require.NoError(t, err)
+
+	comment, err = GetComment(&root, HeadComment, "list")
+	require.NoError(t, err)
+	require.Equal(t, "new comment", comment)
+}
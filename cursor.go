@@ -0,0 +1,115 @@
+package gyml
+
+import (
+	"errors"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cursor points to a path of the tree, so the root and the keys do not have to be repeated for every operation
+// Resolved node is cached, cache stays valid as long as the tree is modified only through the cursor or cursors
+// created from it by Child, Set or Delete of any of them invalidates cached nodes of all of them
+type Cursor struct {
+	root       *yaml.Node
+	keys       []string
+	parent     *Cursor
+	node       *yaml.Node
+	generation int
+	// modifications is shared by cursors created from the same At, node is cached for generation equal to it
+	modifications *int
+}
+
+// At creates cursor pointing to the path
+// Examples:
+// At(&root, "servers", "server1").Set(9001) - cursor can be kept and used for further operations on the same path
+func At(root *yaml.Node, keys ...string) *Cursor {
+	return &Cursor{root: root, keys: slices.Clone(keys), modifications: new(int)}
+}
+
+// Child returns cursor pointing to keys relative to this cursor, resolved node of this cursor is reused
+// Examples:
+// At(&root, "servers").Child("server1", "port")
+func (c *Cursor) Child(keys ...string) *Cursor {
+	return &Cursor{root: c.root, keys: append(slices.Clip(c.keys), keys...), parent: c, modifications: c.modifications}
+}
+
+// Keys returns full path of the cursor
+func (c *Cursor) Keys() []string {
+	return slices.Clone(c.keys)
+}
+
+// Node returns node on the cursor path, document node is unwrapped to its content
+func (c *Cursor) Node() (*yaml.Node, error) {
+	if c.node != nil && c.generation == *c.modifications {
+		return c.node, nil
+	}
+
+	if c.parent == nil {
+		node, err := resolveNode(c.root, c.keys...)
+		if err != nil {
+			return nil, err
+		}
+		c.cache(node)
+		return node, nil
+	}
+
+	parentNode, err := c.parent.Node()
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := resolveNode(parentNode, c.keys[len(c.parent.keys):]...)
+	if err != nil {
+		// report path from the root, not from the parent node
+		var pathErr *PathError
+		if errors.As(err, &pathErr) {
+			return nil, &PathError{Path: append(slices.Clone(c.parent.keys), pathErr.Path...), Err: pathErr.Err, Line: pathErr.Line, Column: pathErr.Column}
+		}
+		return nil, err
+	}
+	c.cache(node)
+	return node, nil
+}
+
+func (c *Cursor) cache(node *yaml.Node) {
+	c.node = node
+	c.generation = *c.modifications
+}
+
+// Decode decodes value on the cursor path into out, which has to be a pointer
+func (c *Cursor) Decode(out any) error {
+	node, err := c.Node()
+	if err != nil {
+		return err
+	}
+	return decodeNode(node, out)
+}
+
+// Set works as SetValue on the cursor path
+func (c *Cursor) Set(data any) error {
+	*c.modifications++
+	node, err := SetValueNode(c.root, data, c.keys...)
+	if err != nil {
+		return err
+	}
+	c.cache(node)
+	return nil
+}
+
+// Delete works as DeleteValue on the cursor path
+func (c *Cursor) Delete() error {
+	*c.modifications++
+	return DeleteValue(c.root, c.keys...)
+}
+
+// GetAt returns value on the cursor path, it works as GetValue
+// Examples:
+// GetAt[int](At(&root, "servers", "server1", "port"))
+func GetAt[DataType any](c *Cursor) (*DataType, error) {
+	var value DataType
+	if err := c.Decode(&value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
@@ -0,0 +1,123 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	servers := At(&root, "servers")
+	server1 := servers.Child("server1")
+
+	host, err := GetAt[string](server1.Child("host"))
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+	require.Equal(t, []string{"servers", "server1", "host"}, server1.Child("host").Keys())
+
+	node, err := server1.Node()
+	require.NoError(t, err)
+
+	cached, err := server1.Node()
+	require.NoError(t, err)
+	require.Same(t, node, cached)
+
+	port := server1.Child("port")
+	err = port.Set(9101)
+	require.NoError(t, err)
+
+	value, err := GetAt[int](port)
+	require.NoError(t, err)
+	require.Equal(t, 9101, *value)
+
+	value, err = GetValue[int](&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9101, *value)
+
+	err = port.Delete()
+	require.NoError(t, err)
+
+	_, err = GetAt[int](port)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.EqualError(t, err, "key not found at servers.server1.port (line 9, col 5)")
+
+	_, err = GetAt[string](servers.Child("server3", "host"))
+	require.EqualError(t, err, "key not found at servers.server3 (line 8, col 3)")
+
+	server3 := At(&root, "servers", "server3")
+	err = server3.Set(map[string]any{"host": "server3.local"})
+	require.NoError(t, err)
+
+	host, err = GetAt[string](server3.Child("host"))
+	require.NoError(t, err)
+	require.Equal(t, "server3.local", *host)
+
+	var ints []int
+	err = At(&root, "ints").Decode(&ints)
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30}, ints)
+
+	_, err = GetAt[int](At(nil, "ints"))
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestCursorInvalidation(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("s:\n  a: 1\n  b: 2\n"), &root)
+	require.NoError(t, err)
+
+	c := At(&root, "s")
+	a := c.Child("a")
+	b := c.Child("b")
+
+	value, err := GetAt[int](a)
+	require.NoError(t, err)
+	require.Equal(t, 1, *value)
+
+	value, err = GetAt[int](b)
+	require.NoError(t, err)
+	require.Equal(t, 2, *value)
+
+	// parent replaced, children see the new content
+	err = c.Set(map[string]int{"a": 10})
+	require.NoError(t, err)
+
+	value, err = GetAt[int](a)
+	require.NoError(t, err)
+	require.Equal(t, 10, *value)
+
+	_, err = GetAt[int](b)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// sibling deleted
+	a2 := c.Child("a")
+	_, err = GetAt[int](a2)
+	require.NoError(t, err)
+
+	err = a.Delete()
+	require.NoError(t, err)
+
+	_, err = GetAt[int](a2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// parent deleted
+	err = c.Set(map[string]int{"a": 20})
+	require.NoError(t, err)
+
+	_, err = GetAt[int](a)
+	require.NoError(t, err)
+
+	err = c.Delete()
+	require.NoError(t, err)
+
+	_, err = GetAt[int](a)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
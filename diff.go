@@ -0,0 +1,276 @@
+package gyml
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeOp defines kind of the change reported by Diff
+type ChangeOp int
+
+const (
+	// path is present only in the second tree
+	ChangeAdded ChangeOp = iota
+	// path is present only in the first tree
+	ChangeRemoved
+	// path is present in both trees with different value
+	ChangeModified
+)
+
+func (op ChangeOp) String() string {
+	switch op {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	}
+	return fmt.Sprintf("ChangeOp(%d)", int(op))
+}
+
+// Change is single difference between two trees
+// Path uses the same keys as GetValue, Old and New are yaml representations of the values (empty when not present)
+type Change struct {
+	Path []string
+	Op   ChangeOp
+	Old  string
+	New  string
+}
+
+// Diff compares tree a with tree b and returns per-path changes needed to turn a into b
+// Mappings are compared key by key, sequences item by item (by index), everything else by decoded value,
+// so comments, styles and mapping key order are not reported as changes
+// Examples:
+// Diff(&current, &migrated) - preview what the migration changes in the config
+func Diff(a, b *yaml.Node) ([]Change, error) {
+	if a == nil || b == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	changes := []Change{}
+	if err := diffNodes(diffContent(a), diffContent(b), []string{}, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// diffContent returns content node of the tree, nil for empty document
+func diffContent(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	if node.Kind == 0 {
+		return nil
+	}
+	return node
+}
+
+func diffNodes(a, b *yaml.Node, path []string, changes *[]Change) error {
+	if a == nil || b == nil {
+		if a == b {
+			return nil
+		}
+		change := Change{Path: path, Op: ChangeModified}
+		var err error
+		if change.Old, err = diffRepresentation(a); err != nil {
+			return err
+		}
+		if change.New, err = diffRepresentation(b); err != nil {
+			return err
+		}
+		*changes = append(*changes, change)
+		return nil
+	}
+
+	if a.Kind == yaml.AliasNode {
+		a = a.Alias
+	}
+	if b.Kind == yaml.AliasNode {
+		b = b.Alias
+	}
+
+	if a.Kind == yaml.MappingNode && b.Kind == yaml.MappingNode {
+		for i := 0; i < len(a.Content); i += 2 {
+			keyPath := appendPath(path, escapeKey(a.Content[i].Value))
+			value := mappingValue(b, a.Content[i].Value)
+			if value == nil {
+				if err := appendChange(changes, keyPath, ChangeRemoved, a.Content[i+1]); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := diffNodes(a.Content[i+1], value, keyPath, changes); err != nil {
+				return err
+			}
+		}
+
+		for i := 0; i < len(b.Content); i += 2 {
+			if mappingValue(a, b.Content[i].Value) == nil {
+				keyPath := appendPath(path, escapeKey(b.Content[i].Value))
+				if err := appendChange(changes, keyPath, ChangeAdded, b.Content[i+1]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if a.Kind == yaml.SequenceNode && b.Kind == yaml.SequenceNode {
+		for i := 0; i < max(len(a.Content), len(b.Content)); i++ {
+			itemPath := appendPath(path, fmt.Sprintf("[%d]", i))
+			var err error
+			switch {
+			case i >= len(b.Content):
+				err = appendChange(changes, itemPath, ChangeRemoved, a.Content[i])
+			case i >= len(a.Content):
+				err = appendChange(changes, itemPath, ChangeAdded, b.Content[i])
+			default:
+				err = diffNodes(a.Content[i], b.Content[i], itemPath, changes)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	oldValue, err := diffRepresentation(a)
+	if err != nil {
+		return err
+	}
+	newValue, err := diffRepresentation(b)
+	if err != nil {
+		return err
+	}
+	if oldValue != newValue {
+		*changes = append(*changes, Change{Path: path, Op: ChangeModified, Old: oldValue, New: newValue})
+	}
+	return nil
+}
+
+func appendChange(changes *[]Change, path []string, op ChangeOp, node *yaml.Node) error {
+	value, err := diffRepresentation(node)
+	if err != nil {
+		return err
+	}
+
+	change := Change{Path: path, Op: op}
+	if op == ChangeAdded {
+		change.New = value
+	} else {
+		change.Old = value
+	}
+	*changes = append(*changes, change)
+	return nil
+}
+
+func diffRepresentation(node *yaml.Node) (string, error) {
+	if node == nil {
+		return "", nil
+	}
+	value, err := serializedValue(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(value, "\n"), nil
+}
+
+// mappingValue returns value of key in mapping node, nil when the key is not present
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Equal reports whether both trees have the same content, comments, styles and mapping key order are ignored
+// Scalars are compared by tag and decoded value (0x10 equals 16), sequences item by item, aliases are resolved
+// Examples:
+// Equal(&merged, &expected) - both merges produced semantically identical config
+func Equal(a, b *yaml.Node) bool {
+	return equalNodes(a, b, false)
+}
+
+// EqualOrdered works as Equal, but mapping keys have to be in the same order in both trees
+// Examples:
+// EqualOrdered(&generated, &expected) - content and key order of generated config match
+func EqualOrdered(a, b *yaml.Node) bool {
+	return equalNodes(a, b, true)
+}
+
+// equalScalars compares tags and decoded values, so formatting like ~ and null or 0x10 and 16 does not matter
+func equalScalars(a, b *yaml.Node) bool {
+	if a.ShortTag() != b.ShortTag() {
+		return false
+	}
+	if a.Value == b.Value {
+		return true
+	}
+
+	aValue, errA := serializedValue(a)
+	bValue, errB := serializedValue(b)
+	return errA == nil && errB == nil && aValue == bValue
+}
+
+// ordered requires the same order of mapping keys
+func equalNodes(a, b *yaml.Node, ordered bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	a = equalContent(a)
+	b = equalContent(b)
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if a.Kind != b.Kind {
+		return false
+	}
+
+	switch a.Kind {
+	case yaml.ScalarNode:
+		return equalScalars(a, b)
+	case yaml.SequenceNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := range a.Content {
+			if !equalNodes(a.Content[i], b.Content[i], ordered) {
+				return false
+			}
+		}
+		return true
+	case yaml.MappingNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := 0; i < len(a.Content); i += 2 {
+			value := mappingValue(b, a.Content[i].Value)
+			if ordered && b.Content[i].Value != a.Content[i].Value {
+				return false
+			}
+			if value == nil || !equalNodes(a.Content[i+1], value, ordered) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// equalContent returns content of document and anchored node of alias, nil for empty document
+func equalContent(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return diffContent(node)
+}
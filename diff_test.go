@@ -0,0 +1,199 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	var a yaml.Node
+	var b yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &a)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(testYAML), &b)
+	require.NoError(t, err)
+
+	changes, err := Diff(&a, &b)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	err = SetValue(&b, 9101, "servers", "server1", "port")
+	require.NoError(t, err)
+	err = DeleteValue(&b, "servers", "server2")
+	require.NoError(t, err)
+	err = SetValue(&b, map[string]any{"host": "server3.local"}, "servers", "server3")
+	require.NoError(t, err)
+	err = SetValue(&b, 40, "ints", "[]")
+	require.NoError(t, err)
+	err = DeleteValue(&b, "clients", "[1]")
+	require.NoError(t, err)
+	err = SetValue(&b, "x", `\[0]`)
+	require.NoError(t, err)
+
+	changes, err = Diff(&a, &b)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Path: []string{"clients", "[1]"}, Op: ChangeRemoved, Old: "name: second_client\nsurname: second_surname"},
+		{Path: []string{"servers", "server1", "port"}, Op: ChangeModified, Old: "9001", New: "9101"},
+		{Path: []string{"servers", "server2"}, Op: ChangeRemoved, Old: "host: server2.local\nport: 9002"},
+		{Path: []string{"servers", "server3"}, Op: ChangeAdded, New: "host: server3.local"},
+		{Path: []string{"ints", "[3]"}, Op: ChangeAdded, New: "40"},
+		{Path: []string{`\[0]`}, Op: ChangeAdded, New: "x"},
+	}, changes)
+
+	changes, err = Diff(&b, &a)
+	require.NoError(t, err)
+	require.Len(t, changes, 6)
+	require.Equal(t, ChangeAdded, changes[0].Op)
+	require.Equal(t, "removed", changes[5].Op.String())
+}
+
+func TestDiffScalars(t *testing.T) {
+	var a yaml.Node
+	var b yaml.Node
+
+	err := yaml.Unmarshal([]byte("# comment\nname: 'web'\nport: 80\nlist: [1, 2]\n"), &a)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte("port: 80\nname: web\nlist: {a: 1}\n"), &b)
+	require.NoError(t, err)
+
+	changes, err := Diff(&a, &b)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Path: []string{"list"}, Op: ChangeModified, Old: "- 1\n- 2", New: "a: 1"},
+	}, changes)
+
+	err = yaml.Unmarshal([]byte("port: '80'\n"), &b)
+	require.NoError(t, err)
+
+	changes, err = Diff(&a, &b)
+	require.NoError(t, err)
+	require.Contains(t, changes, Change{Path: []string{"port"}, Op: ChangeModified, Old: "80", New: `"80"`})
+
+	var empty yaml.Node
+	err = yaml.Unmarshal([]byte(emptyYAML), &empty)
+	require.NoError(t, err)
+
+	changes, err = Diff(&empty, &b)
+	require.NoError(t, err)
+	require.Equal(t, []Change{{Path: []string{}, Op: ChangeModified, New: `port: "80"`}}, changes)
+
+	_, err = Diff(nil, &b)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestEqual(t *testing.T) {
+	var a yaml.Node
+	var b yaml.Node
+
+	err := yaml.Unmarshal([]byte("# config\nname: 'web' # name\nports: [80, 443]\nmeta: {a: 1, b: {c: 2}}\n"), &a)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte("meta:\n  b:\n    c: 2\n  a: 1\nports:\n  - 80\n  - 443\nname: web\n"), &b)
+	require.NoError(t, err)
+
+	require.True(t, Equal(&a, &b))
+	require.True(t, Equal(&b, &a))
+
+	ports, err := resolveNode(&a, "ports")
+	require.NoError(t, err)
+	require.True(t, Equal(ports, &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: "80"},
+		{Kind: yaml.ScalarNode, Value: "443"},
+	}}))
+
+	err = SetValue(&b, "443", "ports", "[1]")
+	require.NoError(t, err)
+	require.False(t, Equal(&a, &b))
+
+	err = SetValue(&b, 443, "ports", "[1]")
+	require.NoError(t, err)
+	require.True(t, Equal(&a, &b))
+
+	err = SetValues(&b, []string{"ports"}, map[string]any{"[]": 8080})
+	require.NoError(t, err)
+	require.False(t, Equal(&a, &b))
+
+	err = DeleteValue(&b, "ports", "[-1]")
+	require.NoError(t, err)
+	err = SetValue(&b, 3, "meta", "b", "d")
+	require.NoError(t, err)
+	require.False(t, Equal(&a, &b))
+
+	var aliased yaml.Node
+	err = yaml.Unmarshal([]byte(aliasYAML), &aliased)
+	require.NoError(t, err)
+
+	_, server1, err := getEntry(&aliased, "servers", "server1")
+	require.NoError(t, err)
+	base, err := resolveNode(&aliased, "base")
+	require.NoError(t, err)
+	require.True(t, Equal(server1, base))
+
+	var empty yaml.Node
+	require.True(t, Equal(&empty, &yaml.Node{Kind: yaml.DocumentNode}))
+	require.False(t, Equal(&empty, &a))
+	require.True(t, Equal(nil, nil))
+	require.False(t, Equal(nil, &a))
+}
+
+func TestEqualScalarFormatting(t *testing.T) {
+	var a yaml.Node
+	var b yaml.Node
+
+	err := yaml.Unmarshal([]byte("none: ~\nhex: 0x10\nfloat: 1.0\nflag: true\ntext: 'a'\n"), &a)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte("none: null\nhex: 16\nfloat: 1.00\nflag: True\ntext: \"a\"\n"), &b)
+	require.NoError(t, err)
+
+	require.True(t, Equal(&a, &b))
+	require.True(t, EqualOrdered(&a, &b))
+
+	changes, err := Diff(&a, &b)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// same value with different tag differs
+	err = SetValue(&b, "16", "hex")
+	require.NoError(t, err)
+	require.False(t, Equal(&a, &b))
+
+	err = SetValue(&b, 17, "hex")
+	require.NoError(t, err)
+	require.False(t, Equal(&a, &b))
+}
+
+func TestEqualOrdered(t *testing.T) {
+	var a yaml.Node
+	var b yaml.Node
+
+	err := yaml.Unmarshal([]byte("name: web\nmeta: {a: 1, b: 2}\nports: [80, 443]\n"), &a)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte("# same order\nname: 'web'\nmeta:\n  a: 1\n  b: 2\nports:\n  - 80\n  - 443\n"), &b)
+	require.NoError(t, err)
+
+	require.True(t, EqualOrdered(&a, &b))
+
+	err = SortKeys(&b)
+	require.NoError(t, err)
+	require.True(t, Equal(&a, &b))
+	require.False(t, EqualOrdered(&a, &b))
+
+	err = yaml.Unmarshal([]byte("name: web\nmeta: {b: 2, a: 1}\nports: [80, 443]\n"), &b)
+	require.NoError(t, err)
+	require.True(t, Equal(&a, &b))
+	require.False(t, EqualOrdered(&a, &b))
+
+	err = yaml.Unmarshal([]byte("name: web\nmeta: {a: 1, b: 2}\nports: [443, 80]\n"), &b)
+	require.NoError(t, err)
+	require.False(t, Equal(&a, &b))
+	require.False(t, EqualOrdered(&a, &b))
+}
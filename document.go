@@ -0,0 +1,148 @@
+package gyml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Documents parses yaml stream with "---" separated documents, every document is returned as separate root node
+// Examples:
+// Documents(manifest) - one root node per kubernetes resource in manifest file
+func Documents(data []byte) ([]*yaml.Node, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	docs := []*yaml.Node{}
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode yaml document: %w", err)
+		}
+		docs = append(docs, &doc)
+	}
+}
+
+// MarshalDocuments serializes documents into single yaml stream with "---" separators, opts are applied as in Marshal
+func MarshalDocuments(docs []*yaml.Node, opts ...MarshalOption) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder, err := newEncoder(&buffer, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range docs {
+		if err := encoder.Encode(doc); err != nil {
+			return nil, fmt.Errorf("cannot encode yaml document: %w", err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("cannot encode yaml document: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Transform decodes documents from in one by one, calls fn for every document and encodes it to out,
+// so only one document is held in memory, documents are separated by "---" as in MarshalDocuments
+// Error of fn stops the transformation, documents processed before are already written to out
+// Examples:
+// Transform(os.Stdin, os.Stdout, func(root *yaml.Node) error { return SetValue(root, 3, "spec", "replicas") })
+func Transform(in io.Reader, out io.Writer, fn func(root *yaml.Node) error, opts ...MarshalOption) error {
+	return TransformCtx(context.Background(), in, out, func(_ context.Context, root *yaml.Node) error {
+		return fn(root)
+	}, opts...)
+}
+
+// TransformCtx works as Transform, but context is checked before every document and passed to fn,
+// so fn can use it e.g. with WalkCtx, the transformation is stopped with ctx.Err() when the context is done
+// Examples:
+// TransformCtx(r.Context(), r.Body, w, func(ctx context.Context, root *yaml.Node) error { return WalkCtx(ctx, root, redact) })
+func TransformCtx(ctx context.Context, in io.Reader, out io.Writer, fn func(ctx context.Context, root *yaml.Node) error, opts ...MarshalOption) error {
+	encoder, err := newEncoder(out, opts...)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(in)
+
+	i := 0
+	for ; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("cannot decode yaml document %d: %w", i, err)
+		}
+
+		if err := fn(ctx, &doc); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+
+		if err := encoder.Encode(&doc); err != nil {
+			return fmt.Errorf("cannot encode yaml document %d: %w", i, err)
+		}
+	}
+
+	// encoder cannot be closed when no document was encoded
+	if i == 0 {
+		return nil
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("cannot encode yaml document: %w", err)
+	}
+	return nil
+}
+
+// GetValueDoc works as GetValue on document with docIndex, negative index counts from the last document
+// Examples:
+// GetValueDoc[string](docs, 1, "metadata", "name") - name of the second resource in manifest
+func GetValueDoc[DataType any](docs []*yaml.Node, docIndex int, keys ...string) (*DataType, error) {
+	doc, err := documentAt(docs, docIndex)
+	if err != nil {
+		return nil, err
+	}
+	return GetValue[DataType](doc, keys...)
+}
+
+// SetValueDoc works as SetValue on document with docIndex, negative index counts from the last document
+func SetValueDoc[DataType any](docs []*yaml.Node, docIndex int, data DataType, keys ...string) error {
+	doc, err := documentAt(docs, docIndex)
+	if err != nil {
+		return err
+	}
+	return SetValue(doc, data, keys...)
+}
+
+// DeleteValueDoc works as DeleteValue on document with docIndex, negative index counts from the last document
+func DeleteValueDoc(docs []*yaml.Node, docIndex int, keys ...string) error {
+	doc, err := documentAt(docs, docIndex)
+	if err != nil {
+		return err
+	}
+	return DeleteValue(doc, keys...)
+}
+
+func documentAt(docs []*yaml.Node, docIndex int) (*yaml.Node, error) {
+	if docIndex < 0 {
+		docIndex += len(docs)
+	}
+
+	if docIndex < 0 || docIndex >= len(docs) {
+		return nil, fmt.Errorf("%w: document %d", ErrIndexOutOfBound, docIndex)
+	}
+	return docs[docIndex], nil
+}
@@ -0,0 +1,131 @@
+package gyml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+const multiDocYAML = `
+kind: Service
+metadata:
+  name: web
+---
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+`
+
+func TestDocuments(t *testing.T) {
+	docs, err := Documents([]byte(multiDocYAML))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	kind, err := GetValueDoc[string](docs, 0, "kind")
+	require.NoError(t, err)
+	require.Equal(t, "Service", *kind)
+
+	replicas, err := GetValueDoc[int](docs, -1, "spec", "replicas")
+	require.NoError(t, err)
+	require.Equal(t, 2, *replicas)
+
+	err = SetValueDoc(docs, 1, 3, "spec", "replicas")
+	require.NoError(t, err)
+
+	err = DeleteValueDoc(docs, 0, "metadata", "name")
+	require.NoError(t, err)
+
+	_, err = GetValueDoc[string](docs, 2, "kind")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValueDoc(docs, -3, 3, "spec", "replicas")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	out, err := MarshalDocuments(docs)
+	require.NoError(t, err)
+
+	docs, err = Documents(out)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	require.False(t, Exists(docs[0], "metadata"))
+
+	replicas, err = GetValueDoc[int](docs, 1, "spec", "replicas")
+	require.NoError(t, err)
+	require.Equal(t, 3, *replicas)
+
+	docs, err = Documents([]byte(""))
+	require.NoError(t, err)
+	require.Len(t, docs, 0)
+
+	_, err = Documents([]byte("a: [1"))
+	require.Error(t, err)
+}
+
+func TestTransform(t *testing.T) {
+	var out bytes.Buffer
+
+	err := Transform(strings.NewReader(multiDocYAML), &out, func(root *yaml.Node) error {
+		kind, err := GetString(root, "kind")
+		if err != nil {
+			return err
+		}
+		if kind == "Deployment" {
+			return SetValue(root, 3, "spec", "replicas")
+		}
+		return SetValue(root, "prod", "metadata", "namespace")
+	}, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "kind: Service\nmetadata:\n  name: web\n  namespace: prod\n---\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: 3\n", out.String())
+
+	out.Reset()
+	errStop := errors.New("stop")
+	err = Transform(strings.NewReader(multiDocYAML), &out, func(root *yaml.Node) error {
+		if Exists(root, "spec") {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	require.EqualError(t, err, "document 1: stop")
+
+	out.Reset()
+	err = Transform(strings.NewReader(""), &out, func(root *yaml.Node) error { return nil })
+	require.NoError(t, err)
+	require.Empty(t, out.String())
+
+	err = Transform(strings.NewReader("a: [1"), &out, func(root *yaml.Node) error { return nil })
+	require.Error(t, err)
+
+	err = Transform(strings.NewReader(multiDocYAML), &out, func(root *yaml.Node) error { return nil }, Indent(-1))
+	require.ErrorIs(t, err, ErrInvalidIndent)
+}
+
+func TestTransformCtx(t *testing.T) {
+	var out bytes.Buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := TransformCtx(ctx, strings.NewReader(multiDocYAML), &out, func(ctx context.Context, root *yaml.Node) error {
+		cancel()
+		return WalkCtx(ctx, root, func(path []string, node *yaml.Node) error { return nil })
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "document 0: context canceled")
+	require.Empty(t, out.String())
+
+	err = TransformCtx(context.Background(), strings.NewReader(multiDocYAML), &out, func(ctx context.Context, root *yaml.Node) error {
+		return SetValue(root, "prod", "metadata", "namespace")
+	}, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "kind: Service\nmetadata:\n  name: web\n  namespace: prod\n---\nkind: Deployment\nmetadata:\n  name: web\n  namespace: prod\nspec:\n  replicas: 2\n", out.String())
+}
@@ -0,0 +1,460 @@
+package gyml
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RenameKey renames the last key of the path in place, position of the key in mapping
+// and its value node (including comments and anchors) are preserved
+// Examples:
+// RenameKey(&root, "primary", "servers", "server1") - servers.server1 is accessible as servers.primary
+func RenameKey(root *yaml.Node, newName string, keys ...string) error {
+	if len(keys) == 0 {
+		return ErrInvalidKeysList
+	}
+
+	parent, err := resolveWritableNode(root, keys[:len(keys)-1]...)
+	if err != nil {
+		return err
+	}
+
+	if parent.Kind != yaml.MappingNode {
+		return newNodeError(ErrUnexpectedNodeKind, keys, parent)
+	}
+
+	if isIndexSyntax(keys[len(keys)-1]) {
+		return newPathError(mappingIndexError(keys[len(keys)-1]), keys)
+	}
+
+	keyIndex := -1
+	for i := 0; i < len(parent.Content); i += 2 {
+		switch {
+		case keyIndex < 0 && defaultNavigator.matchKey(parent.Content[i], keys[len(keys)-1]):
+			keyIndex = i
+		case parent.Content[i].Value == newName:
+			return fmt.Errorf("%w: %s", ErrDuplicateKey, newName)
+		}
+	}
+
+	if keyIndex < 0 {
+		return newPathError(ErrKeyNotFound, keys)
+	}
+
+	// renamed key is always string, e.g. bool key true renamed to "enabled"
+	if isTypedKeyTag(parent.Content[keyIndex].ShortTag()) {
+		parent.Content[keyIndex].Tag = "!!str"
+	}
+	parent.Content[keyIndex].Value = newName
+	return nil
+}
+
+// ReplaceValueNode replaces value node of existing mapping entry or sequence item on the path by newValue,
+// key node stays untouched, so its comments are kept even when e.g. scalar is replaced by mapping
+// Examples:
+// ReplaceValueNode(&root, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, "servers", "server1") - keep "# primary" comment of server1
+func ReplaceValueNode(root *yaml.Node, newValue *yaml.Node, keys ...string) error {
+	if len(keys) == 0 {
+		return ErrInvalidKeysList
+	}
+
+	if newValue == nil {
+		return ErrNilNode
+	}
+
+	parent, err := resolveWritableNode(root, keys[:len(keys)-1]...)
+	if err != nil {
+		return err
+	}
+
+	switch parent.Kind {
+	case yaml.MappingNode:
+		if isIndexSyntax(keys[len(keys)-1]) {
+			return newPathError(mappingIndexError(keys[len(keys)-1]), keys)
+		}
+
+		for i := 0; i < len(parent.Content); i += 2 {
+			if defaultNavigator.matchKey(parent.Content[i], keys[len(keys)-1]) {
+				parent.Content[i+1] = newValue
+				return nil
+			}
+		}
+		return newPathError(ErrKeyNotFound, keys)
+	case yaml.SequenceNode:
+		index, err := parseValidIndex(keys[len(keys)-1], parent)
+		if err != nil {
+			return newPathError(err, keys)
+		}
+		parent.Content[index] = newValue
+		return nil
+	}
+	return newPathError(ErrUnexpectedNodeKind, keys)
+}
+
+// AppendUnique appends data to sequence on the path only when no item of the sequence has the same value,
+// values are compared by their serialized form, missing sequence is created, also in empty document
+// Returns whether data was appended
+// Examples:
+// AppendUnique(&root, "example.com", "allowed_hosts") - add example.com to allowed_hosts once
+func AppendUnique[DataType any](root *yaml.Node, data DataType, keys ...string) (bool, error) {
+	if root == nil {
+		return false, ErrRootNodeNotSet
+	}
+
+	value, err := createContentNode(data)
+	if err != nil {
+		return false, err
+	}
+
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{value}}
+	node, created, err := resolveOrCreate(root, sequence, keys...)
+	if err != nil || created {
+		return created, err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return false, newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	serialized, err := serializedValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range node.Content {
+		itemSerialized, err := serializedValue(item)
+		if err != nil {
+			return false, err
+		}
+		if itemSerialized == serialized {
+			return false, nil
+		}
+	}
+
+	node.Content = append(node.Content, value)
+	return true, nil
+}
+
+// AppendAll appends every item of items to sequence on the path, missing sequence is created
+// All items are encoded before the sequence is modified, so nothing is appended when any item cannot be encoded
+// Examples:
+// AppendAll(&root, []string{"a.example.com", "b.example.com"}, "allowed_hosts") - add both hosts at once
+func AppendAll[DataType any](root *yaml.Node, items []DataType, keys ...string) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	values := make([]*yaml.Node, 0, len(items))
+	for _, item := range items {
+		value, err := createContentNode(item)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: values}
+	node, created, err := resolveOrCreate(root, sequence, keys...)
+	if err != nil || created {
+		return err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	node.Content = append(node.Content, values...)
+	return nil
+}
+
+// UpsertByKey replaces the first mapping item of sequence on the path whose keyField scalar equals keyValue by data,
+// data is appended when no item matches and missing sequence is created, also in empty document
+// data replaces the whole item, so it should contain keyField too, otherwise next upsert does not find it
+// Examples:
+// UpsertByKey(&root, "name", "first_client", Client{Name: "first_client", Surname: "Doe"}, "clients")
+func UpsertByKey[DataType any](root *yaml.Node, keyField string, keyValue string, data DataType, keys ...string) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	value, err := createContentNode(data)
+	if err != nil {
+		return err
+	}
+
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{value}}
+	node, created, err := resolveOrCreate(root, sequence, keys...)
+	if err != nil || created {
+		return err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	if index := indexByKey(node, keyField, keyValue); index >= 0 {
+		node.Content[index] = value
+		return nil
+	}
+	node.Content = append(node.Content, value)
+	return nil
+}
+
+// indexByKey returns index of the first mapping item of sequence whose keyField scalar equals keyValue or -1
+func indexByKey(sequence *yaml.Node, keyField string, keyValue string) int {
+	filter := itemFilter{field: keyField, value: keyValue}
+	for i, item := range sequence.Content {
+		if item.Kind == yaml.AliasNode {
+			item = item.Alias
+		}
+		if item.Kind == yaml.MappingNode && filter.match(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// InsertAt inserts data into sequence on the path before item on index, following items are shifted
+// Index equal to the sequence length appends data, negative index counts from the end of the sequence
+// Examples:
+// InsertAt(&root, "auth", 0, "middlewares") - auth becomes the first middleware
+// InsertAt(&root, "logging", -1, "middlewares") - insert logging before the last middleware
+func InsertAt[DataType any](root *yaml.Node, data DataType, index int, keys ...string) error {
+	node, err := resolveWritableNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	position := index
+	if position < 0 {
+		position += len(node.Content)
+	}
+
+	if position < 0 || position > len(node.Content) {
+		return newPathError(ErrIndexOutOfBound, appendPath(keys, fmt.Sprintf("[%d]", index)))
+	}
+
+	value, err := createContentNode(data)
+	if err != nil {
+		return err
+	}
+
+	node.Content = slices.Insert(node.Content, position, value)
+	return nil
+}
+
+// DeleteWhere removes every item of sequence on the path for which pred returns true and returns number of removed items
+// Alias items are resolved before pred is called, sequence is kept in the document even when all items were removed
+// Examples:
+// DeleteWhere(&root, func(elem *yaml.Node) bool { enabled, err := GetValueAtNode[bool](elem, "enabled"); return err == nil && !*enabled }, "services")
+func DeleteWhere(root *yaml.Node, pred func(elem *yaml.Node) bool, keys ...string) (int, error) {
+	node, err := resolveWritableNode(root, keys...)
+	if err != nil {
+		return 0, err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return 0, newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	removed := 0
+	// backward iteration, so removal does not shift indexes of items which were not visited yet
+	for i := len(node.Content) - 1; i >= 0; i-- {
+		elem := node.Content[i]
+		if elem.Kind == yaml.AliasNode {
+			elem = elem.Alias
+		}
+		if pred(elem) {
+			node.Content = slices.Delete(node.Content, i, i+1)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// RemoveByKey removes the first mapping item of sequence on the path whose keyField scalar equals keyValue
+// Returns whether an item was removed
+// Examples:
+// RemoveByKey(&root, "name", "second_client", "clients")
+func RemoveByKey(root *yaml.Node, keyField string, keyValue string, keys ...string) (bool, error) {
+	node, err := resolveWritableNode(root, keys...)
+	if err != nil {
+		return false, err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return false, newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	index := indexByKey(node, keyField, keyValue)
+	if index < 0 {
+		return false, nil
+	}
+	node.Content = slices.Delete(node.Content, index, index+1)
+	return true, nil
+}
+
+// ReplaceAll calls match for every scalar value of the tree (mapping keys are not included) in document order,
+// when match returns true the value is replaced by returned one, number of replaced values is returned
+// Replaced plain scalars are resolved again, so e.g. replacing "8080" with "web" changes int value to string
+// Examples:
+// ReplaceAll(&root, func(path []string, value string) (string, bool) { return "***", path[len(path)-1] == "password" }) - redact passwords
+func ReplaceAll(root *yaml.Node, match func(path []string, value string) (string, bool)) int {
+	replaced := 0
+	_ = Walk(root, func(path []string, node *yaml.Node) error {
+		if node.Kind != yaml.ScalarNode {
+			return nil
+		}
+
+		value, ok := match(path, node.Value)
+		if !ok {
+			return nil
+		}
+		if value != node.Value && node.Style == 0 {
+			node.Tag = ""
+		}
+		node.Value = value
+		replaced++
+		return nil
+	})
+	return replaced
+}
+
+// Prune removes every empty mapping and sequence from the tree (mapping entries with empty value, empty sequence items)
+// until no empty container is left, content of the document itself and anchored nodes are kept
+// Returns number of removed nodes
+// Examples:
+// Prune(&root) - cleanup "servers: {}" left after deletes done by DeleteValueKeepEmpty
+func Prune(root *yaml.Node) int {
+	if root == nil {
+		return 0
+	}
+
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return 0
+		}
+		root = root.Content[0]
+	}
+
+	total := 0
+	for {
+		removed := pruneNode(root)
+		if removed == 0 {
+			return total
+		}
+		total += removed
+	}
+}
+
+// pruneNode removes empty children of node bottom-up and returns their number
+func pruneNode(node *yaml.Node) int {
+	removed := 0
+	switch node.Kind {
+	case yaml.MappingNode:
+		content := node.Content[:0]
+		for i := 0; i < len(node.Content); i += 2 {
+			removed += pruneNode(node.Content[i+1])
+			if isPrunable(node.Content[i+1]) {
+				removed++
+				continue
+			}
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+		node.Content = content
+	case yaml.SequenceNode:
+		content := node.Content[:0]
+		for _, item := range node.Content {
+			removed += pruneNode(item)
+			if isPrunable(item) {
+				removed++
+				continue
+			}
+			content = append(content, item)
+		}
+		node.Content = content
+	}
+	return removed
+}
+
+func isPrunable(node *yaml.Node) bool {
+	return node.Anchor == "" && isEmptyNode(node)
+}
+
+// TrimOption configures TrimNulls
+type TrimOption func(*trimOptions)
+
+type trimOptions struct {
+	sequenceItems bool
+}
+
+// TrimSequenceItems makes TrimNulls remove null items of sequences too
+func TrimSequenceItems() TrimOption {
+	return func(o *trimOptions) {
+		o.sequenceItems = true
+	}
+}
+
+// TrimNulls removes every mapping entry whose value is explicit null ("key: null", "key: ~", "key:"),
+// anchored nulls are kept as they can be referenced, returns number of removed nodes
+// Examples:
+// TrimNulls(&root) - cleanup optional fields encoded by SetValue as "key: null"
+// TrimNulls(&root, TrimSequenceItems()) - "[1, null, 2]" becomes "[1, 2]"
+func TrimNulls(root *yaml.Node, opts ...TrimOption) int {
+	if root == nil {
+		return 0
+	}
+
+	var options trimOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return 0
+		}
+		root = root.Content[0]
+	}
+	return trimNullsNode(root, options)
+}
+
+// trimNullsNode removes null children of node recursively and returns their number
+func trimNullsNode(node *yaml.Node, options trimOptions) int {
+	removed := 0
+	switch node.Kind {
+	case yaml.MappingNode:
+		content := node.Content[:0]
+		for i := 0; i < len(node.Content); i += 2 {
+			if isTrimmableNull(node.Content[i+1]) {
+				removed++
+				continue
+			}
+			removed += trimNullsNode(node.Content[i+1], options)
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+		node.Content = content
+	case yaml.SequenceNode:
+		content := node.Content[:0]
+		for _, item := range node.Content {
+			if options.sequenceItems && isTrimmableNull(item) {
+				removed++
+				continue
+			}
+			removed += trimNullsNode(item, options)
+			content = append(content, item)
+		}
+		node.Content = content
+	}
+	return removed
+}
+
+func isTrimmableNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Anchor == "" && node.ShortTag() == "!!null"
+}
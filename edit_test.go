@@ -0,0 +1,542 @@
+package gyml
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameKey(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = RenameKey(&root, "primary", "servers", "server1")
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "servers")
+	require.NoError(t, err)
+	require.Equal(t, []string{"primary", "server2"}, keys)
+
+	host, err := GetValue[string](&root, "servers", "primary", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	err = RenameKey(&root, "customers", "clients")
+	require.NoError(t, err)
+
+	keys, err = Keys(&root)
+	require.NoError(t, err)
+	require.Equal(t, []string{"customers", "servers", "ints"}, keys)
+
+	err = RenameKey(&root, "server2", "servers", "primary")
+	require.ErrorIs(t, err, ErrDuplicateKey)
+
+	err = RenameKey(&root, "other", "servers", "server1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	err = RenameKey(&root, "other", "ints", "[0]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.EqualError(t, err, "unexpected node kind provided at ints.[0] (line 15, col 3)")
+
+	err = RenameKey(&root, "other")
+	require.Equal(t, ErrInvalidKeysList, err)
+}
+
+func TestReplaceValueNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("# database settings\ndatabase: localhost\nints: [1, 2]\n"), &root)
+	require.NoError(t, err)
+
+	keyNode, _, err := getEntry(&root, "database")
+	require.NoError(t, err)
+
+	mapping, err := createContentNode(map[string]any{"host": "localhost", "port": 5432})
+	require.NoError(t, err)
+
+	err = ReplaceValueNode(&root, mapping, "database")
+	require.NoError(t, err)
+
+	replacedKey, value, err := getEntry(&root, "database")
+	require.NoError(t, err)
+	require.Same(t, keyNode, replacedKey)
+	require.Same(t, mapping, value)
+
+	err = ReplaceValueNode(&root, &yaml.Node{Kind: yaml.ScalarNode, Value: "3"}, "ints", "[-1]")
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "# database settings\ndatabase:\n  host: localhost\n  port: 5432\nints: [1, 3]\n", string(out))
+
+	err = ReplaceValueNode(&root, mapping, "cache")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	err = ReplaceValueNode(&root, mapping, "ints", "[2]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = ReplaceValueNode(&root, mapping, "database", "host", "name")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = ReplaceValueNode(&root, mapping)
+	require.Equal(t, ErrInvalidKeysList, err)
+
+	err = ReplaceValueNode(&root, nil, "database")
+	require.Equal(t, ErrNilNode, err)
+	require.True(t, Exists(&root, "database", "host"))
+}
+
+func TestAppendUnique(t *testing.T) {
+	var root yaml.Node
+	var rootList yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(listYAML), &rootList)
+	require.NoError(t, err)
+
+	appended, err := AppendUnique(&root, 20, "ints")
+	require.NoError(t, err)
+	require.False(t, appended)
+
+	appended, err = AppendUnique(&root, 40, "ints")
+	require.NoError(t, err)
+	require.True(t, appended)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 40}, *ints)
+
+	appended, err = AppendUnique(&root, map[string]string{"surname": "first_surname", "name": "first_client"}, "clients")
+	require.NoError(t, err)
+	require.False(t, appended)
+
+	appended, err = AppendUnique(&root, "example.com", "allowed_hosts")
+	require.NoError(t, err)
+	require.True(t, appended)
+
+	appended, err = AppendUnique(&root, "example.com", "allowed_hosts")
+	require.NoError(t, err)
+	require.False(t, appended)
+
+	hosts, err := GetValue[[]string](&root, "allowed_hosts")
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com"}, *hosts)
+
+	appended, err = AppendUnique(&rootList, 10)
+	require.NoError(t, err)
+	require.False(t, appended)
+
+	appended, err = AppendUnique(&root, 1, "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.False(t, appended)
+
+	var rootEmpty yaml.Node
+	appended, err = AppendUnique(&rootEmpty, "example.com", "allowed_hosts")
+	require.NoError(t, err)
+	require.True(t, appended)
+
+	var rootEmptyList yaml.Node
+	appended, err = AppendUnique(&rootEmptyList, 10)
+	require.NoError(t, err)
+	require.True(t, appended)
+
+	out, err := Marshal(&rootEmpty)
+	require.NoError(t, err)
+	require.Equal(t, "allowed_hosts:\n    - example.com\n", string(out))
+
+	out, err = Marshal(&rootEmptyList)
+	require.NoError(t, err)
+	require.Equal(t, "- 10\n", string(out))
+}
+
+var errMarshal = errors.New("cannot marshal")
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalYAML() (any, error) {
+	return nil, errMarshal
+}
+
+func TestAppendAll(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = AppendAll(&root, []int{40, 50}, "ints")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 40, 50}, *ints)
+
+	err = AppendAll(&root, []map[string]string{{"name": "third_client"}, {"name": "fourth_client"}}, "clients")
+	require.NoError(t, err)
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "second_client", "third_client", "fourth_client"}, *names)
+
+	err = AppendAll(&root, []string{"a.example.com", "b.example.com"}, "network", "allowed_hosts")
+	require.NoError(t, err)
+
+	hosts, err := GetValue[[]string](&root, "network", "allowed_hosts")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.example.com", "b.example.com"}, *hosts)
+
+	err = AppendAll(&root, []int{}, "ints")
+	require.NoError(t, err)
+
+	length, err := Len(&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, 5, length)
+
+	// nothing is appended when some item cannot be encoded
+	err = AppendAll(&root, []any{60, failingMarshaler{}}, "ints")
+	require.ErrorIs(t, err, errMarshal)
+
+	length, err = Len(&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, 5, length)
+
+	err = AppendAll(&root, []int{1}, "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	var rootEmpty yaml.Node
+	err = AppendAll(&rootEmpty, []int{1, 2}, "list")
+	require.NoError(t, err)
+
+	out, err := Marshal(&rootEmpty)
+	require.NoError(t, err)
+	require.Equal(t, "list:\n    - 1\n    - 2\n", string(out))
+
+	err = AppendAll(nil, []int{1})
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestUpsertByKey(t *testing.T) {
+	var root yaml.Node
+
+	type client struct {
+		Name    string `yaml:"name"`
+		Surname string `yaml:"surname"`
+	}
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = UpsertByKey(&root, "name", "second_client", client{Name: "second_client", Surname: "changed"}, "clients")
+	require.NoError(t, err)
+
+	err = UpsertByKey(&root, "name", "third_client", client{Name: "third_client", Surname: "third_surname"}, "clients")
+	require.NoError(t, err)
+
+	clients, err := GetValue[[]client](&root, "clients")
+	require.NoError(t, err)
+	require.Equal(t, []client{
+		{Name: "first_client", Surname: "first_surname"},
+		{Name: "second_client", Surname: "changed"},
+		{Name: "third_client", Surname: "third_surname"},
+	}, *clients)
+
+	err = UpsertByKey(&root, "name", "first", client{Name: "first"}, "users")
+	require.NoError(t, err)
+
+	users, err := GetValue[[]client](&root, "users")
+	require.NoError(t, err)
+	require.Equal(t, []client{{Name: "first"}}, *users)
+
+	// scalar items are skipped
+	err = UpsertByKey(&root, "name", "10", client{Name: "10"}, "ints")
+	require.NoError(t, err)
+
+	length, err := Len(&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, 4, length)
+
+	err = UpsertByKey(&root, "name", "server1", client{}, "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = UpsertByKey(&root, "name", "x", failingMarshaler{}, "clients")
+	require.ErrorIs(t, err, errMarshal)
+
+	err = UpsertByKey(nil, "name", "x", client{})
+	require.ErrorIs(t, err, ErrRootNodeNotSet)
+
+	var rootEmpty yaml.Node
+	err = UpsertByKey(&rootEmpty, "name", "first", client{Name: "first"}, "clients")
+	require.NoError(t, err)
+
+	err = UpsertByKey(&rootEmpty, "name", "first", client{Name: "first", Surname: "changed"}, "clients")
+	require.NoError(t, err)
+
+	clients, err = GetValue[[]client](&rootEmpty, "clients")
+	require.NoError(t, err)
+	require.Equal(t, []client{{Name: "first", Surname: "changed"}}, *clients)
+}
+
+func TestInsertAt(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = InsertAt(&root, 5, 0, "ints")
+	require.NoError(t, err)
+
+	err = InsertAt(&root, 25, -1, "ints")
+	require.NoError(t, err)
+
+	err = InsertAt(&root, 40, 5, "ints")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 10, 20, 25, 30, 40}, *ints)
+
+	err = InsertAt(&root, 50, 7, "ints")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = InsertAt(&root, 50, -10, "ints")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+	require.EqualError(t, err, "provided index out of bound at ints.[-10]")
+
+	err = InsertAt(&root, map[string]string{"name": "new_client"}, 1, "clients")
+	require.NoError(t, err)
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "new_client", "second_client"}, *names)
+
+	err = InsertAt(&root, 1, 0, "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = InsertAt(&root, 1, 0, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestDeleteWhere(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`disabled: &disabled
+  name: legacy
+  enabled: false
+services:
+  - name: web
+    enabled: true
+  - name: db
+    enabled: false
+  - *disabled
+  - name: cache
+    enabled: false
+  - name: queue
+`), &root)
+	require.NoError(t, err)
+
+	disabled := func(elem *yaml.Node) bool {
+		enabled, err := GetValueAtNode[bool](elem, "enabled")
+		return err == nil && !*enabled
+	}
+
+	removed, err := DeleteWhere(&root, disabled, "services")
+	require.NoError(t, err)
+	require.Equal(t, 3, removed)
+
+	names, err := GetValue[[]string](&root, "services", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"web", "queue"}, *names)
+
+	removed, err = DeleteWhere(&root, disabled, "services")
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+
+	removed, err = DeleteWhere(&root, func(elem *yaml.Node) bool { return true }, "services")
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	length, err := Len(&root, "services")
+	require.NoError(t, err)
+	require.Equal(t, 0, length)
+
+	_, err = DeleteWhere(&root, disabled, "disabled")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	_, err = DeleteWhere(&root, disabled, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = DeleteWhere(nil, disabled)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestRemoveByKey(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	removed, err := RemoveByKey(&root, "name", "first_client", "clients")
+	require.NoError(t, err)
+	require.True(t, removed)
+
+	removed, err = RemoveByKey(&root, "name", "first_client", "clients")
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	clients, err := GetValue[[]map[string]string](&root, "clients")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"name": "second_client", "surname": "second_surname"}}, *clients)
+
+	removed, err = RemoveByKey(&root, "name", "10", "ints")
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	_, err = RemoveByKey(&root, "name", "server1", "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	_, err = RemoveByKey(&root, "name", "x", "users")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestReplaceAll(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`servers:
+  server1:
+    host: api.old.example.com
+    port: 8080
+  server2:
+    host: 'db.old.example.com'
+    port: 5432
+old.example.com: key
+`), &root)
+	require.NoError(t, err)
+
+	replaced := ReplaceAll(&root, func(path []string, value string) (string, bool) {
+		return strings.ReplaceAll(value, "old.example.com", "new.example.com"), strings.Contains(value, "old.example.com")
+	})
+	require.Equal(t, 2, replaced)
+
+	replaced = ReplaceAll(&root, func(path []string, value string) (string, bool) {
+		return "web", slices.Equal(path, []string{"servers", "server1", "port"})
+	})
+	require.Equal(t, 1, replaced)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, `servers:
+  server1:
+    host: api.new.example.com
+    port: web
+  server2:
+    host: 'db.new.example.com'
+    port: 5432
+old.example.com: key
+`, string(out))
+
+	port, err := GetValue[string](&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, "web", *port)
+
+	require.Equal(t, 0, ReplaceAll(&root, func(path []string, value string) (string, bool) { return "", false }))
+	require.Equal(t, 0, ReplaceAll(nil, func(path []string, value string) (string, bool) { return "", true }))
+}
+
+func TestPrune(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`servers:
+  server1: {}
+  server2:
+    tags: []
+    meta:
+      labels: {}
+clients:
+  - {}
+  - []
+  - name: first_client
+    roles: [[], {}]
+shared: &shared {}
+ref: *shared
+port: 9001
+`), &root)
+	require.NoError(t, err)
+
+	removed := Prune(&root)
+	require.Equal(t, 11, removed)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "clients:\n  - name: first_client\nshared: &shared {}\nref: *shared\nport: 9001\n", string(out))
+
+	require.Equal(t, 0, Prune(&root))
+
+	err = yaml.Unmarshal([]byte("a: {}\n"), &root)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, Prune(&root))
+
+	out, err = Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "{}\n", string(out))
+
+	require.Equal(t, 0, Prune(nil))
+}
+
+func TestTrimNulls(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`servers:
+  server1:
+    host: server1.local
+    port: null
+    user: ~
+    alias:
+  server2: null
+clients:
+  - name: first_client
+    surname: null
+  - null
+  - ~
+shared: &shared null
+ref: *shared
+quoted: "null"
+`), &root)
+	require.NoError(t, err)
+
+	clone := CloneNode(&root)
+
+	removed := TrimNulls(&root)
+	require.Equal(t, 5, removed)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, `servers:
+  server1:
+    host: server1.local
+clients:
+  - name: first_client
+  - null
+  - ~
+shared: &shared null
+ref: *shared
+quoted: "null"
+`, string(out))
+
+	require.Equal(t, 0, TrimNulls(&root))
+	require.Equal(t, 7, TrimNulls(clone, TrimSequenceItems()))
+
+	items, err := GetValue[[]map[string]string](clone, "clients")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"name": "first_client"}}, *items)
+
+	require.Equal(t, 0, TrimNulls(nil))
+}
@@ -0,0 +1,73 @@
+package gyml
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GetValueExpand works as GetValue, ${VAR} and $VAR references in string scalars are expanded
+// by environment variables before decoding, the tree itself is not modified
+// Expanded plain scalars are resolved again, so "port: ${PORT}" decodes into int, quoted scalars stay strings
+// Examples:
+// GetValueExpand[string](&root, "database", "password") - "password: ${DB_PASSWORD}" returns value of DB_PASSWORD variable
+func GetValueExpand[DataType any](rootNode *yaml.Node, keys ...string) (*DataType, error) {
+	return GetValueExpandFunc[DataType](rootNode, os.Getenv, keys...)
+}
+
+// GetValueExpandFunc works as GetValueExpand, references are expanded by mapping instead of environment variables
+// Examples:
+// GetValueExpandFunc[int](&root, func(name string) string { return values[name] }, "servers", "server1", "port")
+func GetValueExpandFunc[DataType any](rootNode *yaml.Node, mapping func(string) string, keys ...string) (*DataType, error) {
+	node, err := resolveNode(rootNode, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	// clone contains copies of anchored nodes as well, so expansion through aliases does not modify the tree
+	expanded := CloneNode(node)
+	expandNode(expanded, mapping, map[*yaml.Node]bool{})
+
+	var value DataType
+	if err := decodeNode(expanded, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// expandNode expands references in string scalars of node values, mapping keys are kept as they are
+// Aliases are followed, visited prevents expanding anchored node twice
+func expandNode(node *yaml.Node, mapping func(string) string, visited map[*yaml.Node]bool) {
+	if visited[node] {
+		return
+	}
+	visited[node] = true
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, item := range node.Content {
+			expandNode(item, mapping, visited)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			expandNode(node.Content[i], mapping, visited)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			expandNode(node.Alias, mapping, visited)
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() != "!!str" {
+			return
+		}
+
+		value := os.Expand(node.Value, mapping)
+		if value == node.Value {
+			return
+		}
+		node.Value = value
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+const expandYAML = `
+database:
+  host: ${DB_HOST}
+  port: ${DB_PORT}
+  name: "$DB_PORT"
+  url: postgres://$DB_HOST:${DB_PORT}/app
+  replicas: 2
+`
+
+func TestGetValueExpand(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(expandYAML), &root)
+	require.NoError(t, err)
+
+	t.Setenv("DB_HOST", "db.local")
+	t.Setenv("DB_PORT", "5432")
+
+	host, err := GetValueExpand[string](&root, "database", "host")
+	require.NoError(t, err)
+	require.Equal(t, "db.local", *host)
+
+	port, err := GetValueExpand[int](&root, "database", "port")
+	require.NoError(t, err)
+	require.Equal(t, 5432, *port)
+
+	database, err := GetValueExpand[map[string]any](&root, "database")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"host":     "db.local",
+		"port":     5432,
+		"name":     "5432",
+		"url":      "postgres://db.local:5432/app",
+		"replicas": 2,
+	}, *database)
+
+	// tree itself is not modified
+	raw, err := GetValue[string](&root, "database", "host")
+	require.NoError(t, err)
+	require.Equal(t, "${DB_HOST}", *raw)
+
+	values := map[string]string{"DB_HOST": "other.local"}
+	host, err = GetValueExpandFunc[string](&root, func(name string) string { return values[name] }, "database", "url")
+	require.NoError(t, err)
+	require.Equal(t, "postgres://other.local:/app", *host)
+
+	_, err = GetValueExpand[string](&root, "database", "user")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestGetValueExpandKeysAndAliases(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("u: &u ${H}\ndb:\n  $ref: x\n  url: *u\n  urls: [*u, $H]\n"), &root)
+	require.NoError(t, err)
+
+	values := map[string]string{"H": "db.local", "ref": "Z"}
+	db, err := GetValueExpandFunc[map[string]any](&root, func(name string) string { return values[name] }, "db")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"$ref": "x", "url": "db.local", "urls": []any{"db.local", "db.local"}}, *db)
+
+	// anchored node of the tree is not modified
+	u, err := GetValue[string](&root, "u")
+	require.NoError(t, err)
+	require.Equal(t, "${H}", *u)
+}
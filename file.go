@@ -0,0 +1,147 @@
+package gyml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile reads yaml file and returns its root node
+// Examples:
+// LoadFile("config.yaml") - root node usable with GetValue, SetValue, DeleteValue...
+func LoadFile(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read yaml file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("cannot parse yaml file %s: %w", path, err)
+	}
+	return &root, nil
+}
+
+// SaveFile serializes root node into the file with perm permissions, opts are applied as in Marshal
+// The file is written atomically, data are stored into temporary file in the same directory which is then renamed,
+// so the original file is never left truncated
+func SaveFile(path string, root *yaml.Node, perm os.FileMode, opts ...MarshalOption) error {
+	data, err := Marshal(root, opts...)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data, perm)
+}
+
+// EditFile loads yaml file, calls fn with its root node and saves the file back when fn succeeds
+// The file keeps its permissions, byte order mark, directives (see UnmarshalWithPreamble) and indentation detected
+// from the original content, e.g. 2 spaces stay 2 spaces
+// File with several "---" separated documents is not modified, ErrMultipleDocuments is returned instead
+// Examples:
+// EditFile("config.yaml", func(root *yaml.Node) error { return SetValue(root, 9003, "servers", "server1", "port") })
+func EditFile(path string, fn func(root *yaml.Node) error) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("cannot read yaml file: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read yaml file: %w", err)
+	}
+
+	input, preamble := splitPreamble(data)
+	docs, err := Documents(input)
+	if err != nil {
+		return fmt.Errorf("cannot parse yaml file %s: %w", path, err)
+	}
+	if len(docs) > 1 {
+		return fmt.Errorf("%w: %s contains %d documents", ErrMultipleDocuments, path, len(docs))
+	}
+
+	// empty file is edited as an empty document
+	root := &yaml.Node{}
+	if len(docs) == 1 {
+		root = docs[0]
+	}
+
+	if err := fn(root); err != nil {
+		return err
+	}
+
+	var opts []MarshalOption
+	if indent := detectIndent(data); indent > 0 {
+		opts = append(opts, Indent(indent))
+	}
+
+	out, err := MarshalWithPreamble(root, preamble, opts...)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, out, info.Mode().Perm())
+}
+
+// detectIndent returns indentation of the first nested block of yaml content or 0 when there is none
+// Indentation is measured from the parent key, "- " of sequence items is counted into the parent indentation
+func detectIndent(data []byte) int {
+	parentIndent := -1
+	for _, line := range strings.Split(string(data), "\n") {
+		content := strings.TrimLeft(line, " ")
+		if content == "" || strings.HasPrefix(content, "#") || strings.HasPrefix(content, "%") || strings.HasPrefix(content, "---") {
+			continue
+		}
+
+		indent := len(line) - len(content)
+		if parentIndent >= 0 && indent > parentIndent {
+			return indent - parentIndent
+		}
+
+		parentIndent = -1
+		content, _, _ = strings.Cut(content, " #")
+		if strings.HasSuffix(strings.TrimRight(content, " \r"), ":") {
+			parentIndent = indent
+			for strings.HasPrefix(content, "- ") {
+				trimmed := strings.TrimLeft(content[2:], " ")
+				parentIndent += len(content) - len(trimmed)
+				content = trimmed
+			}
+		}
+	}
+	return 0
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file: %w", err)
+	}
+	// no-op once the file is renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write temporary file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot sync temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot close temporary file: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("cannot set file permissions: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("cannot replace yaml file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package gyml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSaveFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	err := os.WriteFile(path, []byte(testYAML), 0o644)
+	require.NoError(t, err)
+
+	root, err := LoadFile(path)
+	require.NoError(t, err)
+
+	host, err := GetValue[string](root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	err = SetValue(root, "server1.remote", "servers", "server1", "host")
+	require.NoError(t, err)
+
+	err = SaveFile(path, root, 0o600, Indent(2))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "\n  server1:\n    host: server1.remote\n")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	root, err = LoadFile(path)
+	require.NoError(t, err)
+
+	host, err = GetValue[string](root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.remote", *host)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	_, err = LoadFile(filepath.Join(dir, "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = SaveFile(filepath.Join(dir, "missing", "config.yaml"), root, 0o644)
+	require.Error(t, err)
+
+	err = SaveFile(path, nil, 0o644)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestEditFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	err := os.WriteFile(path, []byte("# servers\nservers:\n  server1:\n    host: server1.local # primary\n"), 0o640)
+	require.NoError(t, err)
+
+	err = EditFile(path, func(root *yaml.Node) error {
+		return SetValue(root, 9001, "servers", "server1", "port")
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "# servers\nservers:\n  server1:\n    host: server1.local # primary\n    port: 9001\n", string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	// failing fn leaves the file untouched
+	err = EditFile(path, func(root *yaml.Node) error {
+		if err := DeleteValue(root, "servers"); err != nil {
+			return err
+		}
+		return errMarshal
+	})
+	require.ErrorIs(t, err, errMarshal)
+
+	unchanged, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, unchanged)
+
+	err = EditFile(filepath.Join(dir, "missing.yaml"), func(root *yaml.Node) error { return nil })
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestEditFilePreamble(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	setA := func(root *yaml.Node) error { return SetValue(root, 5, "a") }
+
+	err := os.WriteFile(path, []byte("\ufeff%YAML 1.2\n---\na: 1\nb:\n  c: 2\n"), 0o644)
+	require.NoError(t, err)
+
+	err = EditFile(path, setA)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "\ufeff%YAML 1.2\n---\na: 5\nb:\n  c: 2\n", string(data))
+
+	// documents after the first one are never dropped
+	multi := "a: 1\n---\nb: 2\n"
+	err = os.WriteFile(path, []byte(multi), 0o644)
+	require.NoError(t, err)
+
+	err = EditFile(path, setA)
+	require.ErrorIs(t, err, ErrMultipleDocuments)
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, multi, string(data))
+
+	err = os.WriteFile(path, nil, 0o644)
+	require.NoError(t, err)
+
+	err = EditFile(path, setA)
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "a: 5\n", string(data))
+}
+
+func TestDetectIndent(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected int
+	}{
+		{"a: 1\nb:\n  c: 2\n", 2},
+		{"a:\n    b: 1\n", 4},
+		{"# comment\n\n---\na: # comment\n   b: 1\n", 3},
+		{"list:\n- a:\n    b: 1\n", 2},
+		{"- - a:\n        b: 1\n", 4},
+		{"text: |\n  line\nmap:\n    a: 1\n", 4},
+		{"a: 1\n", 0},
+		{"[1, 2]\n", 0},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, detectIndent([]byte(test.data)), test.data)
+	}
+}
@@ -0,0 +1,175 @@
+package gyml
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Flatten returns every scalar of the tree under its path joined by sep, sequence items use their index as key
+// Empty mappings and sequences are represented by "{}" and "[]" values, aliases are resolved
+// Returns ErrDuplicateKey when two paths result in the same flat key (e.g. "a.b" key and "a" -> "b" keys with "." sep)
+// Examples:
+// Flatten(&root, ".") - {"servers.server1.host": "server1.local", "clients.0.name": "first_client", ...}
+// Flatten(&root, "__") - {"servers__server1__host": "server1.local", ...}, e.g. for environment variables
+func Flatten(root *yaml.Node, sep string) (map[string]string, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return map[string]string{}, nil
+		}
+		root = root.Content[0]
+	}
+
+	flat := map[string]string{}
+	if root.Kind == 0 {
+		return flat, nil
+	}
+
+	if err := flattenNode(root, nil, sep, flat); err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+func flattenNode(node *yaml.Node, path []string, sep string, flat map[string]string) error {
+	if node.Kind == yaml.AliasNode {
+		return flattenNode(node.Alias, path, sep, flat)
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			return addFlatValue(flat, strings.Join(path, sep), "{}")
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			if err := flattenNode(node.Content[i+1], appendPath(path, node.Content[i].Value), sep, flat); err != nil {
+				return err
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			return addFlatValue(flat, strings.Join(path, sep), "[]")
+		}
+		for i, item := range node.Content {
+			if err := flattenNode(item, appendPath(path, strconv.Itoa(i)), sep, flat); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return addFlatValue(flat, strings.Join(path, sep), node.Value)
+}
+
+func addFlatValue(flat map[string]string, key string, value string) error {
+	if _, ok := flat[key]; ok {
+		return fmt.Errorf("%w: flattened key %s", ErrDuplicateKey, key)
+	}
+	flat[key] = value
+	return nil
+}
+
+// flatTree is intermediate tree of flattened keys built by Unflatten
+type flatTree struct {
+	value    *string
+	children map[string]*flatTree
+}
+
+// Unflatten builds document from flattened keys, it is inverse of Flatten
+// Keys are split by sep, children with keys 0..n-1 become sequence items, other children become mapping entries
+// Values "{}" and "[]" become empty mapping and sequence, other values are plain scalars resolved as yaml does (9001 is int)
+// Key which is value and parent at the same time (e.g. "a" and "a.b") results in ErrScalarSetAttempt
+// Examples:
+// Unflatten(map[string]string{"servers.server1.host": "server1.local", "ints.0": "10"}, ".")
+func Unflatten(flat map[string]string, sep string) (*yaml.Node, error) {
+	if sep == "" {
+		return nil, fmt.Errorf("%w: empty separator", ErrInvalidPath)
+	}
+
+	root := &yaml.Node{Kind: yaml.DocumentNode}
+	if len(flat) == 0 {
+		return root, nil
+	}
+
+	tree := &flatTree{}
+	for _, key := range slices.Sorted(maps.Keys(flat)) {
+		var segments []string
+		if key != "" {
+			segments = strings.Split(key, sep)
+		}
+		if err := tree.insert(segments, flat[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	root.Content = append(root.Content, tree.node())
+	return root, nil
+}
+
+func (t *flatTree) insert(segments []string, value string) error {
+	current := t
+	for i, segment := range segments {
+		if current.value != nil {
+			return newPathError(ErrScalarSetAttempt, segments[:i])
+		}
+		if current.children == nil {
+			current.children = map[string]*flatTree{}
+		}
+		child, ok := current.children[segment]
+		if !ok {
+			child = &flatTree{}
+			current.children[segment] = child
+		}
+		current = child
+	}
+
+	if len(current.children) > 0 {
+		return newPathError(ErrScalarSetAttempt, segments)
+	}
+	current.value = &value
+	return nil
+}
+
+func (t *flatTree) node() *yaml.Node {
+	if t.value != nil {
+		switch *t.value {
+		case "{}":
+			return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{}}
+		case "[]":
+			return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{}}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: *t.value}
+	}
+
+	if t.isSequence() {
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: make([]*yaml.Node, 0, len(t.children))}
+		for i := range len(t.children) {
+			node.Content = append(node.Content, t.children[strconv.Itoa(i)].node())
+		}
+		return node
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: make([]*yaml.Node, 0, 2*len(t.children))}
+	for _, key := range slices.Sorted(maps.Keys(t.children)) {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, t.children[key].node())
+	}
+	return node
+}
+
+// isSequence reports whether children keys are exactly 0..n-1
+func (t *flatTree) isSequence() bool {
+	for i := range len(t.children) {
+		if _, ok := t.children[strconv.Itoa(i)]; !ok {
+			return false
+		}
+	}
+	return true
+}
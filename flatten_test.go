@@ -0,0 +1,113 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatten(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	flat, err := Flatten(&root, ".")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"clients.0.name":       "first_client",
+		"clients.0.surname":    "first_surname",
+		"clients.1.name":       "second_client",
+		"clients.1.surname":    "second_surname",
+		"servers.server1.host": "server1.local",
+		"servers.server1.port": "9001",
+		"servers.server2.host": "server2.local",
+		"servers.server2.port": "9002",
+		"ints.0":               "10",
+		"ints.1":               "20",
+		"ints.2":               "30",
+	}, flat)
+
+	err = yaml.Unmarshal([]byte(aliasYAML+"empty_map: {}\nempty_list: []\n"), &root)
+	require.NoError(t, err)
+
+	flat, err = Flatten(&root, "__")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"base__host":             "base.local",
+		"base__port":             "9000",
+		"servers__server1__host": "base.local",
+		"servers__server1__port": "9000",
+		"servers__server2__host": "server2.local",
+		"empty_map":              "{}",
+		"empty_list":             "[]",
+	}, flat)
+
+	err = yaml.Unmarshal([]byte("a.b: 1\na:\n  b: 2\n"), &root)
+	require.NoError(t, err)
+
+	_, err = Flatten(&root, ".")
+	require.ErrorIs(t, err, ErrDuplicateKey)
+
+	var empty yaml.Node
+	err = yaml.Unmarshal([]byte(emptyYAML), &empty)
+	require.NoError(t, err)
+
+	flat, err = Flatten(&empty, ".")
+	require.NoError(t, err)
+	require.Empty(t, flat)
+
+	_, err = Flatten(nil, ".")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestUnflatten(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"empty_map: {}\nempty_list: []\n"), &root)
+	require.NoError(t, err)
+
+	flat, err := Flatten(&root, ".")
+	require.NoError(t, err)
+
+	unflattened, err := Unflatten(flat, ".")
+	require.NoError(t, err)
+
+	changes, err := Diff(&root, unflattened)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	port, err := GetValue[int](unflattened, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9001, *port)
+
+	unflattened, err = Unflatten(map[string]string{"a__1": "x", "a__2": "y", "b__0": "z", "b__1": "w", "b__10": "v"}, "__")
+	require.NoError(t, err)
+
+	out, err := Marshal(unflattened, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "a:\n  \"1\": x\n  \"2\": y\nb:\n  \"0\": z\n  \"1\": w\n  \"10\": v\n", string(out))
+
+	unflattened, err = Unflatten(map[string]string{"ints.1": "20", "ints.0": "10", "ints.2": "30"}, ".")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](unflattened, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30}, *ints)
+
+	_, err = Unflatten(map[string]string{"a.b": "1", "a": "2"}, ".")
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+	require.EqualError(t, err, "cannot set under scalar at a")
+
+	_, err = Unflatten(map[string]string{"a.b.c": "1", "a.b": "2"}, ".")
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+
+	unflattened, err = Unflatten(map[string]string{}, ".")
+	require.NoError(t, err)
+	require.Equal(t, yaml.DocumentNode, unflattened.Kind)
+
+	_, err = Unflatten(flat, "")
+	require.ErrorIs(t, err, ErrInvalidPath)
+}
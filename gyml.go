@@ -3,6 +3,7 @@ package gyml
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"strconv"
@@ -19,9 +20,66 @@ var (
 	ErrInvalidIndexFormat = errors.New("invalid index format")
 	ErrIndexOutOfBound    = errors.New("provided index out of bound")
 	ErrInvalidKeysList    = errors.New("invalid keys list")
-	ErrScalarSetAttempt   = errors.New("cannot iterate over scalar node")
+	ErrScalarSetAttempt   = errors.New("cannot set under scalar")
+	ErrInvalidPath        = errors.New("invalid path format")
+	ErrCircularMove       = errors.New("cannot move node into itself")
+	ErrDuplicateKey       = errors.New("key already exists")
+	ErrSkipSubtree        = errors.New("skip subtree")
+	ErrAliasTraversal     = errors.New("cannot navigate through alias node")
+	ErrUnknownOperation   = errors.New("unknown patch operation")
+	ErrInvalidOutput      = errors.New("output has to be non-nil pointer")
+	ErrUnknownKey         = errors.New("unknown key")
+	ErrInvalidIndent      = errors.New("indent cannot be negative")
+	ErrMultipleDocuments  = errors.New("multiple documents are not supported")
+	ErrNilNode            = errors.New("node cannot be nil")
 )
 
+// index key selecting all items of sequence node
+const wildcardIndex = "[*]"
+
+// key selecting all values of mapping node
+const wildcardKey = "{*}"
+
+// PathError records failed path lookup, Path contains keys up to the one where the failure happened
+// Err is one of the package errors, so errors.Is(err, ErrKeyNotFound) works on PathError as well
+// Line and Column are source position of the existing node where the failure happened, e.g. of mapping
+// with missing key, they are zero when the node was not parsed from source
+type PathError struct {
+	Path   []string
+	Err    error
+	Line   int
+	Column int
+}
+
+func (e *PathError) Error() string {
+	message := e.Err.Error()
+	if len(e.Path) > 0 {
+		message += " at " + strings.Join(e.Path, ".")
+	}
+	if e.Line > 0 {
+		message += fmt.Sprintf(" (line %d, col %d)", e.Line, e.Column)
+	}
+	return message
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+func newPathError(err error, path []string) error {
+	return &PathError{Path: path, Err: err}
+}
+
+// newNodeError creates PathError with source position of node where the failure happened
+func newNodeError(err error, path []string, node *yaml.Node) error {
+	return &PathError{Path: path, Err: err, Line: node.Line, Column: node.Column}
+}
+
+// appendPath returns new path with key appended, path itself is never modified
+func appendPath(path []string, key string) []string {
+	return append(slices.Clip(path), key)
+}
+
 // Returns error on failure
 // Examples:
 // SetValue is used to set values on key's path, if some part of the path does not exist, it is created with correct type based on the keys ([] -> sequence type, "name" -> map type)
@@ -32,8 +90,30 @@ var (
 // SetValue(&root, TestSomeStruct{Name: "Adam", Age: 30}, "Company", "CEO") - set TestSomeStruct on /Company/CEO
 // SetValue(&root, 35, "some_list", "[]") - append new item 35 to some_list sequence
 // SetValue(&root, 12, "some_list", "[8]") - set 12 in some_list at index[8] (range check involved)
+// SetValue(&root, 12, "some_list", "[3]") - append 12 to some_list when it has 3 items, same as "[]"
+// SetValue(&root, 12, "new_list", "[2]") - create new_list with 12 at index 2, items 0 and 1 are nulls
+// SetValue(&root, "web", "services", "[]", "name") - append new item {name: web} to services, services is created when missing
+// SetValue(&root, 8080, "services", "[-1]", "port") - add port to the last existing item of services, e.g. to the one appended above
 // SetValue(&root, "Matus", "Company", "CEO", "Name") - scalar value settings at /Company/CEO/Name to Matus
+// SetValue(&root, "x", "Company", `\[]`) - set literal "[]" key of Company mapping, leading backslash escapes index syntax
+// SetValue(&root, Config{...}) - no keys replace whole content of the document, e.g. to initialize new document from struct
 func SetValue[DataType any](root *yaml.Node, data DataType, keys ...string) error {
+	_, err := SetValueNode(root, data, keys...)
+	return err
+}
+
+// SetValueNode works as SetValue and returns node which was written on the path
+// Examples:
+// SetValueNode(&root, 9003, "servers", "server3", "port") - created port node, e.g. to set its comment right away
+func SetValueNode[DataType any](root *yaml.Node, data DataType, keys ...string) (*yaml.Node, error) {
+	return defaultNavigator.setValue(root, data, keys...)
+}
+
+// SetValueRaw places value node on the path as it is, without encoding, so its style, tag, anchor and comments are kept
+// Missing part of the path is created in the same way as SetValue does
+// Examples:
+// SetValueRaw(&root, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!secret", Value: "db-password"}, "database", "password")
+func SetValueRaw(root *yaml.Node, value *yaml.Node, keys ...string) error {
 	if len(keys) == 0 {
 		return ErrInvalidKeysList
 	}
@@ -42,57 +122,440 @@ func SetValue[DataType any](root *yaml.Node, data DataType, keys ...string) erro
 		return ErrRootNodeNotSet
 	}
 
-	return setValue(root, data, keys...)
+	if value == nil {
+		return ErrNilNode
+	}
+
+	_, err := setNode(root, value, keys...)
+	return err
 }
 
-func DeleteValue(root *yaml.Node, keys ...string) error {
+// SetValueTag places scalar with exactly given value and tag on the path, tag is not inferred from the value
+// Missing part of the path is created in the same way as SetValue does
+// Examples:
+// SetValueTag(&root, "true", "!!str", "feature", "flag") - flag: "true" stays string after parsing
+// SetValueTag(&root, "aGVsbG8=", "!!binary", "payload") - payload: !!binary aGVsbG8=
+func SetValueTag(root *yaml.Node, value string, tag string, keys ...string) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	_, err := setNode(root, &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}, keys...)
+	return err
+}
+
+// SetNull sets explicit null on the path, key is kept in the document as "key: null" unlike DeleteValue removes it
+// Missing part of the path is created in the same way as SetValue does
+// Examples:
+// SetNull(&root, "servers", "server1", "port") - tombstone port which downstream tooling expects to be present
+func SetNull(root *yaml.Node, keys ...string) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	_, err := setNode(root, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, keys...)
+	return err
+}
 
+// SetValueIfAbsent sets data on the path only when the path does not exist yet, returns whether the value was set
+// Existing intermediate node of unexpected kind (e.g. scalar in the middle of the path) is reported as error
+// Examples:
+// SetValueIfAbsent(&root, 8080, "servers", "server1", "port") - set default port, keep the configured one
+func SetValueIfAbsent[DataType any](root *yaml.Node, data DataType, keys ...string) (bool, error) {
 	if len(keys) == 0 {
-		return ErrInvalidKeysList
+		return false, ErrInvalidKeysList
+	}
+
+	if root == nil {
+		return false, ErrRootNodeNotSet
+	}
+
+	value, err := createContentNode(data)
+	if err != nil {
+		return false, err
+	}
+
+	_, created, err := resolveOrCreate(root, value, keys...)
+	return created, err
+}
+
+// GetOrCreate returns node on the path, missing path is created in the same way as SetValue does
+// with empty mapping as the last node, returned node is linked into the tree, so it can be edited further
+// Wildcard, range and filter keys are rejected by ErrInvalidKeysList as they do not address single node of the tree
+// Examples:
+// GetOrCreate(&root, "servers", "server3") - existing or new empty server3 mapping, e.g. to SetValue into it
+// GetOrCreate(&root, "services", "[]") - new empty mapping appended to services
+func GetOrCreate(root *yaml.Node, keys ...string) (*yaml.Node, error) {
+	node, _, err := resolveOrCreate(root, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, keys...)
+	return node, err
+}
+
+// Touch makes sure mapping exists on the path, missing nodes are created as empty mappings
+// Existing mapping is left untouched, ErrUnexpectedNodeKind is returned when existing node is not a mapping
+// Examples:
+// Touch(&root, "servers", "server3") - servers.server3 exists afterwards, possibly as "server3: {}"
+func Touch(root *yaml.Node, keys ...string) error {
+	node, err := GetOrCreate(root, keys...)
+	if err != nil {
+		return err
 	}
 
+	if node.Kind != yaml.MappingNode {
+		return newNodeError(ErrUnexpectedNodeKind, keys, node)
+	}
+	return nil
+}
+
+// SetValues sets every value of values map on prefix + key path, keys are processed in sorted order
+// so created keys appear in deterministic order, first failure stops the processing and is returned
+// Examples:
+// SetValues(&root, []string{"servers", "server3"}, map[string]any{"host": "server3.local", "port": 9003})
+func SetValues(root *yaml.Node, prefix []string, values map[string]any) error {
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if err := SetValue(root, values[key], appendPath(prefix, key)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteValue deletes node on the path, mappings and sequences which became empty by the delete are removed as well,
+// anchored ones are kept, so aliases referencing them stay valid
+// Examples:
+// DeleteValue(&root, "servers", "server1") - delete server1, servers is deleted too when server1 was its only key
+func DeleteValue(root *yaml.Node, keys ...string) error {
+	return defaultNavigator.Delete(root, keys...)
+}
+
+// MissingMode defines how batch operations handle paths which do not exist
+type MissingMode int
+
+const (
+	// missing path is skipped
+	IgnoreMissing MissingMode = iota
+	// missing path stops the batch with ErrKeyNotFound or ErrIndexOutOfBound
+	StrictMissing
+)
+
+// DeleteValues deletes every path as DeleteValue does and returns number of paths which existed and were deleted
+// With IgnoreMissing paths which do not exist are skipped, any other error stops the batch
+// Examples:
+// DeleteValues(&root, [][]string{{"legacy"}, {"servers", "server1", "timeout"}}, IgnoreMissing) - prune obsolete fields
+func DeleteValues(root *yaml.Node, paths [][]string, mode MissingMode) (int, error) {
+	deleted := 0
+	for _, keys := range paths {
+		err := DeleteValue(root, keys...)
+		if err != nil {
+			if mode == IgnoreMissing && isMissingPath(err) {
+				continue
+			}
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// GetValues decodes value of every path in paths into out under the same name, names are processed in sorted order
+// Non-nil pointer already stored in out under the name is used as decoding target, otherwise value is decoded into any
+// With IgnoreMissing names of paths which do not exist are left untouched in out, any other error stops the batch
+// Examples:
+// GetValues(&root, map[string][]string{"host": {"servers", "server1", "host"}, "port": {"servers", "server1", "port"}}, out, IgnoreMissing)
+// GetValues(&root, map[string][]string{"port": {"servers", "server1", "port"}}, map[string]any{"port": &port}, StrictMissing) - decode into port variable
+func GetValues(root *yaml.Node, paths map[string][]string, out map[string]any, mode MissingMode) error {
 	if root == nil {
 		return ErrRootNodeNotSet
 	}
-	return deleteValue(root, keys...)
+	if out == nil {
+		return ErrInvalidOutput
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(paths)) {
+		node, err := defaultNavigator.GetNode(root, paths[name]...)
+		if err != nil {
+			if mode == IgnoreMissing && isMissingPath(err) {
+				continue
+			}
+			return fmt.Errorf("value %s: %w", name, err)
+		}
+
+		if target := reflect.ValueOf(out[name]); target.Kind() == reflect.Pointer && !target.IsNil() {
+			if err := decodeNode(node, out[name]); err != nil {
+				return fmt.Errorf("value %s: %w", name, err)
+			}
+			continue
+		}
+
+		var value any
+		if err := decodeNode(node, &value); err != nil {
+			return fmt.Errorf("value %s: %w", name, err)
+		}
+		out[name] = value
+	}
+	return nil
+}
+
+// DeleteValueNode works as DeleteValue and returns copy of the deleted node, e.g. to undo the delete by SetValueRaw
+// Deleted alias is returned as copy of its anchored node, deleted range as sequence of the deleted items
+// Examples:
+// DeleteValueNode(&root, "servers", "server1") - removed server1 mapping
+func DeleteValueNode(root *yaml.Node, keys ...string) (*yaml.Node, error) {
+	if len(keys) == 0 {
+		return nil, ErrInvalidKeysList
+	}
+
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := CloneNode(node)
+	if err := DeleteValue(root, keys...); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// SetValueStrict works as SetValue, but missing intermediate nodes are not created, only the last key can be missing
+// ErrKeyNotFound with path of the first missing key is returned otherwise
+// Examples:
+// SetValueStrict(&root, 9003, "servers", "server1", "port") - set or add port of existing server1
+// SetValueStrict(&root, 9003, "severs", "server1", "port") - fails with "key not found at severs" instead of creating severs
+func SetValueStrict[DataType any](root *yaml.Node, data DataType, keys ...string) error {
+	return NewNavigator(WithNoCreateParents()).Set(root, data, keys...)
+}
+
+// DeleteValueKeepEmpty works as DeleteValue, but parents which became empty are kept in the document
+// Examples:
+// DeleteValueKeepEmpty(&root, "servers", "server1") - delete server1, keep "servers: {}" when it was the only one
+func DeleteValueKeepEmpty(root *yaml.Node, keys ...string) error {
+	return NewNavigator(WithKeepEmptyParents()).Delete(root, keys...)
 }
 
 // Returns values on the path defined by list of keys
+// Either non-nil value and nil error or nil value and non-nil error is returned, never both nil
+// Document without content decodes as zero value when no keys are given, with keys ErrEmptyDocumentNode is returned
 // Examples:
 // GetValue[int](&number, "persons_list", "[10]", "age") - get age property of 10th person in person_list, deserialize to *int
+// GetValue[[]string](&root, "clients", "[*]", "name") - get name property of every item in clients, deserialize to *[]string
+// GetValue[[]int](&root, "ints", "[1:3]") - get items 1 and 2 of ints, open ranges like [2:] or [:2] are supported too
+// GetValue[[]string](&root, "servers", "{*}", "host") - get host property of every value of servers mapping in document order
 func GetValue[DataType any](rootNode *yaml.Node, keys ...string) (*DataType, error) {
-
-	if rootNode == nil {
-		return nil, ErrRootNodeNotSet
+	var value DataType
+	if err := defaultNavigator.Get(rootNode, &value, keys...); err != nil {
+		return nil, err
 	}
+	return &value, nil
+}
 
-	node, err := getValue(rootNode, keys...)
+// GetNode returns node on the path without decoding, the node is part of the tree, so it can be edited in place
+// Examples:
+// GetNode(&root, "servers", "server1") - server1 mapping node, e.g. to pass it to SetValue as root of another edit
+func GetNode(rootNode *yaml.Node, keys ...string) (*yaml.Node, error) {
+	return defaultNavigator.GetNode(rootNode, keys...)
+}
+
+// GetNodes returns items of sequence on the path without decoding, so kind of every item can be inspected
+// before decoding it, alias items are resolved, returned slice is a copy, but items are part of the tree
+// Examples:
+// GetNodes(&root, "clients") - client nodes, e.g. to decode mappings by GetValueAtNode and skip other kinds
+func GetNodes(rootNode *yaml.Node, keys ...string) ([]*yaml.Node, error) {
+	node, err := resolveNode(rootNode, keys...)
 	if err != nil {
 		return nil, err
 	}
 
-	var value DataType
-	if err := node.Decode(&value); err != nil {
-		return nil, fmt.Errorf("GetValue: cannot decode yaml node value: %w", err)
+	if node.Kind != yaml.SequenceNode {
+		return nil, newNodeError(ErrUnexpectedNodeKind, keys, node)
+	}
+
+	items := make([]*yaml.Node, 0, len(node.Content))
+	for _, item := range node.Content {
+		if item.Kind == yaml.AliasNode {
+			item = item.Alias
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// GetValueInto decodes value on the path into out provided by caller, which has to be non-nil pointer
+// Unlike GetValue no new value is allocated, so it can fill existing struct or field of a larger struct
+// Examples:
+// GetValueInto(&root, &cfg.Server, "servers", "server1") - decode server1 directly into field of existing config
+func GetValueInto(rootNode *yaml.Node, out any, keys ...string) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return ErrInvalidOutput
+	}
+	return defaultNavigator.Get(rootNode, out, keys...)
+}
+
+// Returns values on the path relative to node, node can be any node of the tree (e.g. obtained from Walk or ForEach)
+// not only document root
+// Examples:
+// GetValueAtNode[string](clientNode, "name") - get name property of client mapping node
+func GetValueAtNode[DataType any](node *yaml.Node, keys ...string) (*DataType, error) {
+	return GetValue[DataType](node, keys...)
+}
+
+// Returns value on the path or def when the path does not exist, any other failure also results in def
+// Use GetValueOrErr when decode or node kind errors need to be distinguished
+// Examples:
+// GetValueOr(&root, 8080, "servers", "server1", "port") - port of server1 or 8080 when not configured
+func GetValueOr[DataType any](rootNode *yaml.Node, def DataType, keys ...string) DataType {
+	value, err := GetValueOrErr(rootNode, def, keys...)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// Returns value on the path or def when the path does not exist (ErrKeyNotFound, ErrIndexOutOfBound)
+// All other errors are returned together with def
+func GetValueOrErr[DataType any](rootNode *yaml.Node, def DataType, keys ...string) (DataType, error) {
+	value, err := GetValue[DataType](rootNode, keys...)
+	if err != nil {
+		if isMissingPath(err) {
+			return def, nil
+		}
+		return def, err
+	}
+	return *value, nil
+}
+
+// Returns value on primary path or on fallback path when primary path does not exist (ErrKeyNotFound, ErrIndexOutOfBound)
+// All other errors of primary path (e.g. decode errors) are returned without trying fallback path
+// Examples:
+// GetValueOrPath[int](&root, []string{"features", "search", "timeout"}, []string{"defaults", "timeout"}) - feature timeout or global default
+func GetValueOrPath[DataType any](rootNode *yaml.Node, primary []string, fallback []string) (*DataType, error) {
+	value, err := GetValue[DataType](rootNode, primary...)
+	if err != nil && isMissingPath(err) {
+		return GetValue[DataType](rootNode, fallback...)
+	}
+	return value, err
+}
+
+// MustGetValue returns value on the path and panics on error, it is intended for tests and static configs
+// Examples:
+// MustGetValue[int](&root, "servers", "server1", "port")
+func MustGetValue[DataType any](rootNode *yaml.Node, keys ...string) DataType {
+	value, err := GetValue[DataType](rootNode, keys...)
+	if err != nil {
+		panic(err)
+	}
+	return *value
+}
+
+// GetString returns string value on the path, zero value is returned on error
+func GetString(rootNode *yaml.Node, keys ...string) (string, error) {
+	return getTypedValue[string](rootNode, keys...)
+}
+
+// GetInt returns int value on the path, zero value is returned on error
+func GetInt(rootNode *yaml.Node, keys ...string) (int, error) {
+	return getTypedValue[int](rootNode, keys...)
+}
+
+// GetBool returns bool value on the path, zero value is returned on error
+func GetBool(rootNode *yaml.Node, keys ...string) (bool, error) {
+	return getTypedValue[bool](rootNode, keys...)
+}
+
+// GetFloat64 returns float64 value on the path, zero value is returned on error
+func GetFloat64(rootNode *yaml.Node, keys ...string) (float64, error) {
+	return getTypedValue[float64](rootNode, keys...)
+}
+
+// GetTagged returns value on the path decoded into any together with resolved tag of the node, e.g. "!!int" or "!!str",
+// so the type of ambiguous scalars like quoted "yes" or "10" is not lost, custom tags like "!secret" are returned as they are
+// Examples:
+// GetTagged(&root, "servers", "server1", "port") - 9001, "!!int"
+// GetTagged(&root, "answer") - "yes", "!!str" for answer: 'yes'
+func GetTagged(rootNode *yaml.Node, keys ...string) (any, string, error) {
+	node, err := resolveNode(rootNode, keys...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var value any
+	if err := decodeNode(node, &value); err != nil {
+		return nil, "", err
+	}
+	return value, node.ShortTag(), nil
+}
+
+func getTypedValue[DataType any](rootNode *yaml.Node, keys ...string) (DataType, error) {
+	value, err := GetValue[DataType](rootNode, keys...)
+	if err != nil {
+		var zero DataType
+		return zero, err
+	}
+	return *value, nil
+}
+
+// path lookup failed only because some key or index does not exist
+func isMissingPath(err error) bool {
+	return errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrIndexOutOfBound)
+}
+
+// resolveOrCreate returns existing node on the path, missing path (also in empty document) is created
+// with value as the last node, created reports whether value was linked into the tree
+func resolveOrCreate(root *yaml.Node, value *yaml.Node, keys ...string) (node *yaml.Node, created bool, err error) {
+	node, err = resolveWritableNode(root, keys...)
+	if err == nil {
+		return node, false, nil
+	}
+	if !isMissingPath(err) && !errors.Is(err, ErrEmptyDocumentNode) {
+		return nil, false, err
+	}
+
+	if _, err := setNode(root, value, keys...); err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// errIndexGrammar describes accepted index format, it is returned for every malformed index
+var errIndexGrammar = fmt.Errorf("%w: expected [n] or [-n] where n is decimal number without + sign, spaces or leading zeros", ErrInvalidIndexFormat)
+
+// parseIndexNumber parses number of index or range bound, only -?(0|[1-9][0-9]*) is accepted
+func parseIndexNumber(number string) (int, error) {
+	digits := strings.TrimPrefix(number, "-")
+	if digits == "" || (len(digits) > 1 && digits[0] == '0') {
+		return 0, errIndexGrammar
+	}
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, errIndexGrammar
+		}
 	}
-	normalizeEmptySlice(&value)
-	return &value, nil
 
+	index, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, errIndexGrammar
+	}
+	return index, nil
 }
 
 func parseValidIndex(indexStr string, node *yaml.Node) (int, error) {
 	if len(indexStr) < 3 {
-		return 0, ErrInvalidIndexFormat
+		return 0, errIndexGrammar
 	}
 
 	if indexStr[0] != '[' || indexStr[len(indexStr)-1] != ']' {
-		return 0, ErrInvalidIndexFormat
+		return 0, errIndexGrammar
 	}
 
-	index, err := strconv.Atoi(indexStr[1 : len(indexStr)-1])
-
+	index, err := parseIndexNumber(indexStr[1 : len(indexStr)-1])
 	if err != nil {
-		return 0, ErrInvalidIndexFormat
+		return 0, err
+	}
+
+	// negative index counts from the end of the sequence, [-1] is the last item
+	if index < 0 {
+		index += len(node.Content)
 	}
 
 	if index < 0 || index >= len(node.Content) {
@@ -102,87 +565,364 @@ func parseValidIndex(indexStr string, node *yaml.Node) (int, error) {
 	return index, nil
 }
 
-func setValue[DataType any](root *yaml.Node, data DataType, keys ...string) error {
-	if root.Kind == yaml.ScalarNode {
-		return fmt.Errorf("%w: %s", ErrScalarSetAttempt, keys[0])
+// isNextIndex reports whether indexStr is [n] index with n equal to length of the sequence
+func isNextIndex(indexStr string, node *yaml.Node) bool {
+	if len(indexStr) < 3 || indexStr[0] != '[' || indexStr[len(indexStr)-1] != ']' {
+		return false
+	}
+
+	index, err := parseIndexNumber(indexStr[1 : len(indexStr)-1])
+	return err == nil && index == len(node.Content)
+}
+
+func isRangeIndex(indexStr string) bool {
+	return len(indexStr) >= 3 && indexStr[0] == '[' && indexStr[len(indexStr)-1] == ']' && strings.Contains(indexStr, ":")
+}
+
+// parseRange parses [start:end] range of sequence items, both bounds are optional and can be negative
+func parseRange(indexStr string, node *yaml.Node) (int, int, error) {
+	startStr, endStr, _ := strings.Cut(indexStr[1:len(indexStr)-1], ":")
+
+	parseBound := func(bound string, def int) (int, error) {
+		if bound == "" {
+			return def, nil
+		}
+		index, err := parseIndexNumber(bound)
+		if err != nil {
+			return 0, err
+		}
+		if index < 0 {
+			index += len(node.Content)
+		}
+		return index, nil
+	}
+
+	start, err := parseBound(startStr, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := parseBound(endStr, len(node.Content))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if start < 0 || end > len(node.Content) || start > end {
+		return 0, 0, ErrIndexOutOfBound
+	}
+	return start, end, nil
+}
+
+// setNode places value node on the path, missing part of the path is created
+// Returns node which was replaced by value, nil when value was added
+func setNode(node *yaml.Node, value *yaml.Node, keys ...string) (*yaml.Node, error) {
+	return defaultNavigator.setNodeFrom(node, value, nil, keys...)
+}
+
+// path contains keys already consumed by the recursion, it is used for error reporting
+func (n *Navigator) setNodeFrom(node *yaml.Node, value *yaml.Node, path []string, keys ...string) (*yaml.Node, error) {
+	node, err := n.followAlias(node, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// zero node (e.g. unmarshaled empty input) is handled as an empty document
+	if node.Kind == 0 {
+		node.Kind = yaml.DocumentNode
+	}
+
+	if node.Kind == yaml.DocumentNode {
+		// empty keys replace whole content of the document
+		if len(keys) == 0 && len(node.Content) > 0 {
+			old := node.Content[0]
+			node.Content[0] = value
+			return old, nil
+		}
+
+		if len(node.Content) > 0 {
+			return n.setNodeFrom(node.Content[0], value, path, keys...)
+		}
+
+		if err := n.checkEnvelope(path, keys); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, createNodeEnvelope(value, keys...))
+		return nil, nil
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrInvalidKeysList
 	}
 
-	if root.Kind == yaml.DocumentNode {
-		if len(root.Content) > 0 {
-			return setValue(root.Content[0], data, keys...)
+	if node.Kind == yaml.SequenceNode {
+		// [] always appends new item, [-1] descends into the last existing item instead
+		if keys[0] == "[]" {
+			if err := n.checkEnvelope(path, keys); err != nil {
+				return nil, err
+			}
+			appendNodeToContent(node, value, keys...)
+			return nil, nil
+		}
+
+		if !strings.HasPrefix(keys[0], "[") {
+			return nil, newNodeError(sequenceKeyError(keys[0]), appendPath(path, keys[0]), node)
+		}
+
+		// index right after the last item appends, same as [] does
+		if isNextIndex(keys[0], node) {
+			if err := n.checkEnvelope(path, keys); err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, createNodeEnvelope(value, keys[1:]...))
+			return nil, nil
+		}
+
+		index, err := parseValidIndex(keys[0], node)
+		if err != nil {
+			return nil, newNodeError(err, appendPath(path, keys[0]), node)
+		}
+
+		if len(keys) == 1 {
+			old := node.Content[index]
+			node.Content[index] = value
+			return old, nil
 		}
+		return n.setNodeFrom(node.Content[index], value, appendPath(path, keys[0]), keys[1:]...)
+	}
+
+	if node.Kind == yaml.MappingNode {
+		// index cannot address item of mapping, literal key has to be escaped
+		if isIndexSyntax(keys[0]) {
+			return nil, newNodeError(mappingIndexError(keys[0]), appendPath(path, keys[0]), node)
+		}
+
+		if keys[0] == wildcardKey {
+			return nil, newNodeError(fmt.Errorf("%w: wildcard cannot be used to set value", ErrInvalidKeysList), appendPath(path, keys[0]), node)
+		}
+
+		for i := 0; i < len(node.Content); i += 2 {
+			if n.matchKey(node.Content[i], keys[0]) {
+				if len(keys) == 1 {
+					old := node.Content[i+1]
+					node.Content[i+1] = value
+					return old, nil
+				}
+				return n.setNodeFrom(node.Content[i+1], value, appendPath(path, keys[0]), keys[1:]...)
+			}
+		}
+
+		if err := n.checkEnvelope(path, keys); err != nil {
+			return nil, err
+		}
+		appendNodeToContent(node, value, keys...)
+		return nil, nil
+	}
+
+	// path points to the scalar itself, keys[0] is the key which was requested under it
+	if node.Kind == yaml.ScalarNode {
+		return nil, newNodeError(ErrScalarSetAttempt, path, node)
+	}
+
+	return nil, newNodeError(ErrUnexpectedNodeKind, appendPath(path, keys[0]), node)
+}
+
+// sequenceKeyError describes mapping key used on existing sequence node
+func sequenceKeyError(key string) error {
+	return fmt.Errorf("%w: sequence found, index expected instead of key %s", ErrUnexpectedNodeKind, key)
+}
+
+// mappingIndexError describes index used on existing mapping node
+func mappingIndexError(key string) error {
+	return fmt.Errorf("%w: mapping found, key expected instead of index %s (literal key has to be escaped as %s)", ErrUnexpectedNodeKind, key, escapeKey(key))
+}
 
-		return appendDataToContent(root, data, keys...)
+// preserveScalarStyle carries style, tag and comments of overwritten scalar to the new scalar of the same type,
+// so e.g. single quoted 'yes' stays single quoted after overwrite
+func preserveScalarStyle(old, value *yaml.Node) {
+	if old.Kind != yaml.ScalarNode || value.Kind != yaml.ScalarNode || old.ShortTag() != value.ShortTag() {
+		return
 	}
 
-	return fmt.Errorf("%w: key: %s", ErrUnexpectedNodeKind, keys[0])
+	value.Style = old.Style
+	value.Tag = old.Tag
+	if value.HeadComment == "" && value.LineComment == "" && value.FootComment == "" {
+		value.HeadComment = old.HeadComment
+		value.LineComment = old.LineComment
+		value.FootComment = old.FootComment
+	}
 }
 
 func getValue(node *yaml.Node, keys ...string) (*yaml.Node, error) {
+	return defaultNavigator.getValueFrom(node, nil, keys...)
+}
+
+// path contains keys already consumed by the recursion, it is used for error reporting
+func (n *Navigator) getValueFrom(node *yaml.Node, path []string, keys ...string) (*yaml.Node, error) {
 
 	// final recursion
 	if len(keys) == 0 {
+		if node.Kind == yaml.AliasNode && !n.stopAtAliases {
+			return node.Alias, nil
+		}
 		return node, nil
 	}
 
+	node, err := n.followAlias(node, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// zero node (e.g. unmarshaled empty input) is handled as an empty document
+	if node.Kind == 0 || (node.Kind == yaml.DocumentNode && len(node.Content) == 0) {
+		return nil, newPathError(ErrEmptyDocumentNode, path)
+	}
+
 	if node.Kind == yaml.DocumentNode {
-		if len(node.Content) == 0 {
-			return nil, ErrEmptyDocumentNode
-		}
-		return getValue(node.Content[0], keys...)
+		return n.getValueFrom(node.Content[0], path, keys...)
 	}
 
 	if node.Kind == yaml.SequenceNode {
+		if keys[0] == wildcardIndex {
+			return n.getSequenceValues(node, path, 0, len(node.Content), keys[1:]...)
+		}
+
+		if isRangeIndex(keys[0]) {
+			start, end, err := parseRange(keys[0], node)
+			if err != nil {
+				return nil, newNodeError(err, appendPath(path, keys[0]), node)
+			}
+			return n.getSequenceValues(node, path, start, end, keys[1:]...)
+		}
+
+		if !strings.HasPrefix(keys[0], "[") {
+			return nil, newNodeError(sequenceKeyError(keys[0]), appendPath(path, keys[0]), node)
+		}
+
 		index, err := parseValidIndex(keys[0], node)
 		if err != nil {
-			return nil, err
+			return nil, newNodeError(err, appendPath(path, keys[0]), node)
 		}
 
-		return getValue(node.Content[index], keys[1:]...)
+		return n.getValueFrom(node.Content[index], appendPath(path, keys[0]), keys[1:]...)
 	}
 
 	if node.Kind == yaml.MappingNode {
+		if isIndexSyntax(keys[0]) {
+			return nil, newNodeError(mappingIndexError(keys[0]), appendPath(path, keys[0]), node)
+		}
+
+		if keys[0] == wildcardKey {
+			return n.getMappingValues(node, path, keys[1:]...)
+		}
+
 		// Content is sorted as key1,value1,key2,value2...
 		for i := 0; i < len(node.Content); i += 2 {
-			if node.Content[i].Value == keys[0] {
-				return getValue(node.Content[i+1], keys[1:]...)
+			if n.matchKey(node.Content[i], keys[0]) {
+				return n.getValueFrom(node.Content[i+1], appendPath(path, keys[0]), keys[1:]...)
 			}
 		}
-		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keys[0])
+		return nil, newNodeError(ErrKeyNotFound, appendPath(path, keys[0]), node)
+	}
+
+	return nil, newNodeError(ErrUnexpectedNodeKind, appendPath(path, keys[0]), node)
+}
+
+// collect values on the rest of the path from sequence items in [start, end) range into new sequence node
+func (n *Navigator) getSequenceValues(node *yaml.Node, path []string, start, end int, keys ...string) (*yaml.Node, error) {
+	result := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{}}
+	for i := start; i < end; i++ {
+		value, err := n.getValueFrom(node.Content[i], appendPath(path, fmt.Sprintf("[%d]", i)), keys...)
+		if err != nil {
+			return nil, err
+		}
+		result.Content = append(result.Content, value)
 	}
+	return result, nil
+}
 
-	return nil, fmt.Errorf("%w: key: %s", ErrUnexpectedNodeKind, keys[0])
+// collect values on the rest of the path from all values of mapping node in document order into new sequence node
+func (n *Navigator) getMappingValues(node *yaml.Node, path []string, keys ...string) (*yaml.Node, error) {
+	result := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{}}
+	for i := 0; i < len(node.Content); i += 2 {
+		value, err := n.getValueFrom(node.Content[i+1], appendPath(path, escapeKey(node.Content[i].Value)), keys...)
+		if err != nil {
+			return nil, err
+		}
+		result.Content = append(result.Content, value)
+	}
+	return result, nil
 }
 
-func normalizeEmptySlice[T any](v *T) {
-	if v == nil {
+// normalizeEmptySlice replaces nil slice pointed by v with empty slice
+func normalizeEmptySlice(v any) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
 		return
 	}
 
-	rv := reflect.ValueOf(v).Elem()
+	rv = rv.Elem()
 	if rv.Kind() == reflect.Slice && rv.IsNil() {
 		rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
 	}
 }
 
+// normalizeEmptyMap replaces nil map pointed by v with empty map
+func normalizeEmptyMap(v any) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() == reflect.Map && rv.IsNil() {
+		rv.Set(reflect.MakeMap(rv.Type()))
+	}
+}
+
 func deleteValue(node *yaml.Node, keys ...string) error {
+	return defaultNavigator.deleteValueFrom(node, nil, keys...)
+}
+
+// path contains keys already consumed by the recursion, it is used for error reporting
+func (n *Navigator) deleteValueFrom(node *yaml.Node, path []string, keys ...string) error {
 
 	if len(keys) == 0 || node == nil {
 		return ErrInvalidKeysList
 	}
 
+	node, err := n.followAlias(node, path)
+	if err != nil {
+		return err
+	}
+
 	if node.Kind == yaml.DocumentNode {
 		if len(node.Content) > 0 {
-			return deleteValue(node.Content[0], keys...)
+			return n.deleteValueFrom(node.Content[0], path, keys...)
 		}
-		return ErrEmptyDocumentNode
+		return newPathError(ErrEmptyDocumentNode, path)
 	}
 
 	if node.Kind == yaml.SequenceNode {
 
+		if isRangeIndex(keys[0]) {
+			if len(keys) > 1 {
+				return newNodeError(fmt.Errorf("%w: range must be the last key", ErrInvalidKeysList), appendPath(path, keys[0]), node)
+			}
+			start, end, err := parseRange(keys[0], node)
+			if err != nil {
+				return newNodeError(err, appendPath(path, keys[0]), node)
+			}
+			node.Content = slices.Delete(node.Content, start, end)
+			return nil
+		}
+
+		if !strings.HasPrefix(keys[0], "[") {
+			return newNodeError(sequenceKeyError(keys[0]), appendPath(path, keys[0]), node)
+		}
+
 		index, err := parseValidIndex(keys[0], node)
 		if err != nil {
-			return err
+			return newNodeError(err, appendPath(path, keys[0]), node)
 		}
 
 		if len(keys) == 1 {
@@ -190,9 +930,9 @@ func deleteValue(node *yaml.Node, keys ...string) error {
 			return nil
 		}
 
-		retVal := deleteValue(node.Content[index], keys[1:]...)
-		// delete empty list itself when it is empty after deleting my last child
-		if retVal == nil && isEmptyNode(node.Content[index]) {
+		retVal := n.deleteValueFrom(node.Content[index], appendPath(path, keys[0]), keys[1:]...)
+		// delete empty list itself when it is empty after deleting my last child, anchored one is kept for its aliases
+		if retVal == nil && !n.keepEmptyParents && isPrunable(node.Content[index]) {
 			node.Content = slices.Delete(node.Content, index, index+1)
 		}
 
@@ -200,30 +940,34 @@ func deleteValue(node *yaml.Node, keys ...string) error {
 	}
 
 	if node.Kind == yaml.MappingNode {
+		if isIndexSyntax(keys[0]) {
+			return newNodeError(mappingIndexError(keys[0]), appendPath(path, keys[0]), node)
+		}
+
 		for i := 0; i < len(node.Content); i += 2 {
-			if node.Content[i].Value == keys[0] {
+			if n.matchKey(node.Content[i], keys[0]) {
 				if len(keys) == 1 {
 					node.Content = slices.Delete(node.Content, i, i+2)
 					return nil
 				}
 				valueNode := node.Content[i+1]
-				retVal := deleteValue(valueNode, keys[1:]...)
+				retVal := n.deleteValueFrom(valueNode, appendPath(path, keys[0]), keys[1:]...)
 
-				// delete empty map itself when it is empty after deleting my last child
-				if retVal == nil && isEmptyNode(valueNode) {
+				// delete empty map itself when it is empty after deleting my last child, anchored one is kept for its aliases
+				if retVal == nil && !n.keepEmptyParents && isPrunable(valueNode) {
 					node.Content = slices.Delete(node.Content, i, i+2)
 				}
 				return retVal
 			}
 		}
-		return fmt.Errorf("%w: %s", ErrKeyNotFound, keys[0])
+		return newNodeError(ErrKeyNotFound, appendPath(path, keys[0]), node)
 	}
 
 	if node.Kind == yaml.ScalarNode {
-		return fmt.Errorf("%w: unresolved path: %s", ErrInvalidKeysList, strings.Join(keys, "."))
+		return newNodeError(fmt.Errorf("%w: unresolved path: %s", ErrInvalidKeysList, strings.Join(keys, ".")), path, node)
 	}
 
-	return fmt.Errorf("%w: key: %s", ErrUnexpectedNodeKind, keys[0])
+	return newNodeError(ErrUnexpectedNodeKind, appendPath(path, keys[0]), node)
 }
 
 func isEmptyNode(node *yaml.Node) bool {
@@ -233,23 +977,86 @@ func isEmptyNode(node *yaml.Node) bool {
 	return false
 }
 
-// createTypedEnvelope recursively wraps the provided data into a nested structure
-// of maps or slices based on the provided restKeys.
-// If a key is "[]", it wraps the result in a slice.
-// Otherwise, it wraps the result in a map with the key as the property name.
-func createTypedEnvelope[DataType any](data DataType, restKeys ...string) any {
+// createNodeEnvelope recursively wraps the provided value node into a nested structure
+// of mapping or sequence nodes based on the provided restKeys.
+// If a key is "[]", it wraps the result in a sequence node.
+// If a key is "[n]", it wraps the result in a sequence node at index n, preceding items are nulls.
+// Otherwise, it wraps the result in a mapping node with the key as the property name.
+func createNodeEnvelope(value *yaml.Node, restKeys ...string) *yaml.Node {
 	if len(restKeys) == 0 {
-		// Base case: no more keys to process, return the raw data
-		return data
+		// Base case: no more keys to process, return the value itself
+		return value
 	}
 
 	if restKeys[0] == "[]" {
-		// List envelope: wrap the next level in a slice
-		return []any{createTypedEnvelope(data, restKeys[1:]...)}
+		// List envelope: wrap the next level in a sequence
+		return &yaml.Node{
+			Kind:    yaml.SequenceNode,
+			Tag:     "!!seq",
+			Content: []*yaml.Node{createNodeEnvelope(value, restKeys[1:]...)},
+		}
 	}
 
-	// Map envelope: wrap the next level in a map using the current key
-	return map[string]any{restKeys[0]: createTypedEnvelope(data, restKeys[1:]...)}
+	if index, ok := creationIndex(restKeys[0]); ok {
+		// List envelope with index: items before the index are null placeholders
+		content := make([]*yaml.Node, 0, index+1)
+		for range index {
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+		}
+		return &yaml.Node{
+			Kind:    yaml.SequenceNode,
+			Tag:     "!!seq",
+			Content: append(content, createNodeEnvelope(value, restKeys[1:]...)),
+		}
+	}
+
+	// Map envelope: wrap the next level in a mapping using the current key
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: unescapeKey(restKeys[0])},
+			createNodeEnvelope(value, restKeys[1:]...),
+		},
+	}
+}
+
+// creationIndex returns n of non-negative [n] index key, such key creates sequence when the path is missing
+func creationIndex(key string) (int, bool) {
+	index, ok := parseIndexKey(key)
+	if !ok || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// parseIndexKey returns n of [n] index key, n can be negative
+func parseIndexKey(key string) (int, bool) {
+	if len(key) < 3 || key[0] != '[' || key[len(key)-1] != ']' {
+		return 0, false
+	}
+
+	index, err := parseIndexNumber(key[1 : len(key)-1])
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
+
+// checkEnvelope reports whether missing path defined by keys can be created, keys[0] is the first missing key
+func (n *Navigator) checkEnvelope(path []string, keys []string) error {
+	if n.noCreateParents && len(keys) > 1 {
+		return newPathError(ErrKeyNotFound, appendPath(path, keys[0]))
+	}
+
+	// negative index refers to existing item, so it cannot be created
+	for i, key := range keys {
+		if index, ok := parseIndexKey(key); ok && index < 0 {
+			err := fmt.Errorf("%w: %s refers to existing item, but the sequence does not exist yet", ErrIndexOutOfBound, key)
+			return newPathError(err, append(slices.Clip(path), keys[:i+1]...))
+		}
+	}
+	return nil
 }
 
 // wrap any data in ContentNode to add/append to another Node
@@ -262,11 +1069,22 @@ func createContentNode[DataType any](data DataType) (*yaml.Node, error) {
 	return &node, nil
 }
 
-func appendDataToContent[DataType any](node *yaml.Node, data DataType, keys ...string) error {
-	contentNode, err := createContentNode(createTypedEnvelope(data, keys...))
+// serializedValue returns yaml representation of decoded node value,
+// comments, styles and mapping key order do not affect the result
+func serializedValue(node *yaml.Node) (string, error) {
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return "", fmt.Errorf("cannot decode yaml node value: %w", err)
+	}
+
+	out, err := yaml.Marshal(value)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("cannot encode value to yaml: %w", err)
 	}
-	node.Content = append(node.Content, contentNode.Content...)
-	return nil
+	return string(out), nil
+}
+
+// append enveloped value to mapping or sequence node, keys[0] is the first missing key of the path
+func appendNodeToContent(node *yaml.Node, value *yaml.Node, keys ...string) {
+	node.Content = append(node.Content, createNodeEnvelope(value, keys...).Content...)
 }
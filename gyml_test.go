@@ -1,6 +1,7 @@
 package gyml
 
 import (
+	"errors"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -73,8 +74,8 @@ func TestGetValue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []int{10, 20}, *ints)
 
-	ints, err = GetValue[[]int](&rootList, "[*]")
-	require.Equal(t, ErrInvalidIndexFormat, err)
+	ints, err = GetValue[[]int](&rootList, "[x]")
+	require.ErrorIs(t, err, ErrInvalidIndexFormat)
 	require.Nil(t, ints)
 
 	val, err := GetValue[int](&rootList, "[1]")
@@ -86,15 +87,27 @@ func TestGetValue(t *testing.T) {
 	require.Equal(t, *val, 10)
 
 	val, err = GetValue[int](&rootList, "[-1]")
-	require.Equal(t, ErrIndexOutOfBound, err)
+	require.NoError(t, err)
+	require.Equal(t, *val, 20)
+
+	val, err = GetValue[int](&rootList, "[-2]")
+	require.NoError(t, err)
+	require.Equal(t, *val, 10)
+
+	val, err = GetValue[int](&rootList, "[-3]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
 	require.Nil(t, val)
 
+	last_client, err := GetValue[string](&root, "clients", "[-1]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "second_client", *last_client)
+
 	val, err = GetValue[int](&rootList, "[3]")
-	require.Equal(t, ErrIndexOutOfBound, err)
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
 	require.Nil(t, val)
 
 	val, err = GetValue[int](&rootList, "[25]")
-	require.Equal(t, ErrIndexOutOfBound, err)
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
 	require.Nil(t, val)
 
 	ints, err = GetValue[[]int](&rootEmpty)
@@ -102,6 +115,37 @@ func TestGetValue(t *testing.T) {
 	require.Equal(t, []int{}, *ints)
 }
 
+func TestDeleteValueKeepsAnchors(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("base: &b\n  x: 1\nprod: *b\nlist:\n  - &item [1]\n  - *item\n"), &root)
+	require.NoError(t, err)
+
+	err = DeleteValue(&root, "base", "x")
+	require.NoError(t, err)
+
+	err = DeleteValue(&root, "list", "[0]", "[0]")
+	require.NoError(t, err)
+
+	out, err := Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, "base: &b {}\nprod: *b\nlist:\n    - &item []\n    - *item\n", string(out))
+
+	var reparsed yaml.Node
+	require.NoError(t, yaml.Unmarshal(out, &reparsed))
+
+	// anchored mapping emptied by move stays as well
+	err = yaml.Unmarshal([]byte("base: &b\n  x: 1\nprod: *b\n"), &root)
+	require.NoError(t, err)
+
+	err = MoveValue(&root, []string{"base", "x"}, []string{"moved"})
+	require.NoError(t, err)
+
+	out, err = Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, "base: &b {}\nprod: *b\nmoved: 1\n", string(out))
+}
+
 func TestDeleteValue(t *testing.T) {
 	var root yaml.Node
 	var rootList yaml.Node
@@ -138,11 +182,18 @@ func TestDeleteValue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, *val, 30)
 
+	err = DeleteValue(&root, "ints", "[-1]")
+	require.NoError(t, err)
+
+	val, err = GetValue[int](&root, "ints", "[-1]")
+	require.NoError(t, err)
+	require.Equal(t, *val, 10)
+
 	err = DeleteValue(&root, "ints", "[-25]")
-	require.Equal(t, ErrIndexOutOfBound, err)
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
 
 	err = DeleteValue(&root, "ints", "[25]")
-	require.Equal(t, ErrIndexOutOfBound, err)
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
 
 	err = DeleteValue(&root, "ints")
 	require.NoError(t, err)
@@ -155,6 +206,1602 @@ func TestDeleteValue(t *testing.T) {
 	require.Equal(t, *val, 10)
 
 	err = DeleteValue(&rootList, "[1]")
-	require.Equal(t, ErrIndexOutOfBound, err)
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+}
+
+func TestDeleteValueNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	before, err := Marshal(&root)
+	require.NoError(t, err)
+
+	deleted, err := DeleteValueNode(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.False(t, Exists(&root, "servers", "server1"))
+
+	host, err := GetValueAtNode[string](deleted, "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	ints, err := DeleteValueNode(&root, "ints", "[:2]")
+	require.NoError(t, err)
+
+	values, err := GetValueAtNode[[]int](ints)
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20}, *values)
+
+	// undo
+	err = SetValueRaw(&root, deleted, "servers", "server1")
+	require.NoError(t, err)
+
+	err = SortKeys(&root, "servers")
+	require.NoError(t, err)
+
+	err = InsertAt(&root, 20, 0, "ints")
+	require.NoError(t, err)
+	err = InsertAt(&root, 10, 0, "ints")
+	require.NoError(t, err)
+
+	after, err := Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+
+	_, err = DeleteValueNode(&root, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = DeleteValueNode(&root)
+	require.Equal(t, ErrInvalidKeysList, err)
+}
+
+func TestDeleteValueKeepEmpty(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = DeleteValueKeepEmpty(&root, "servers", "server1", "host")
+	require.NoError(t, err)
+
+	err = DeleteValueKeepEmpty(&root, "servers", "server1", "port")
+	require.NoError(t, err)
+
+	kind, err := NodeKind(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, kind)
+
+	err = DeleteValueKeepEmpty(&root, "servers", "server1")
+	require.NoError(t, err)
+
+	err = DeleteValueKeepEmpty(&root, "servers", "server2")
+	require.NoError(t, err)
+
+	err = DeleteValueKeepEmpty(&root, "ints", "[:]")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{}, *ints)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Contains(t, string(out), "servers: {}\nints: []\n")
+
+	// default delete removes parents which became empty
+	err = DeleteValue(&root, "clients", "[0]", "name")
+	require.NoError(t, err)
+	err = DeleteValue(&root, "clients", "[0]", "surname")
+	require.NoError(t, err)
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second_client"}, *names)
+
+	err = DeleteValueKeepEmpty(&root, "servers", "server1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestDeleteValues(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	deleted, err := DeleteValues(&root, [][]string{
+		{"servers", "server1", "port"},
+		{"servers", "server9"},
+		{"ints", "[5]"},
+		{"ints", "[0]"},
+	}, IgnoreMissing)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 30}, *ints)
+	require.False(t, Exists(&root, "servers", "server1", "port"))
+
+	deleted, err = DeleteValues(&root, [][]string{{"ints", "[0]"}, {"servers", "server9"}, {"ints", "[0]"}}, StrictMissing)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, 1, deleted)
+
+	deleted, err = DeleteValues(&root, [][]string{{"servers", "server1", "host", "name"}, {"ints"}}, IgnoreMissing)
+	require.Error(t, err)
+	require.Equal(t, 0, deleted)
+	require.True(t, Exists(&root, "ints"))
+}
+
+func TestGetValues(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	port := 0
+	out := map[string]any{"port": &port}
+	err = GetValues(&root, map[string][]string{
+		"host":    {"servers", "server1", "host"},
+		"port":    {"servers", "server2", "port"},
+		"ints":    {"ints"},
+		"missing": {"servers", "server9", "host"},
+	}, out, IgnoreMissing)
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", out["host"])
+	require.Equal(t, 9002, port)
+	require.Equal(t, []any{10, 20, 30}, out["ints"])
+	require.NotContains(t, out, "missing")
+
+	out = map[string]any{}
+	err = GetValues(&root, map[string][]string{"missing": {"servers", "server9"}}, out, StrictMissing)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.EqualError(t, err, "value missing: key not found at servers.server9 (line 8, col 3)")
+
+	err = GetValues(&root, map[string][]string{"bad": {"servers", "server1", "host", "name"}}, out, IgnoreMissing)
+	require.Error(t, err)
+
+	err = GetValues(&root, nil, nil, IgnoreMissing)
+	require.ErrorIs(t, err, ErrInvalidOutput)
+}
+
+func TestSetValue(t *testing.T) {
+	var root yaml.Node
+	var rootEmpty yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(emptyYAML), &rootEmpty)
+	require.NoError(t, err)
+
+	err = SetValue(&root, "server1.remote", "servers", "server1", "host")
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.remote", *host)
+
+	err = SetValue(&root, 9003, "servers", "server3", "port")
+	require.NoError(t, err)
+
+	port, err := GetValue[int](&root, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9003, *port)
+
+	err = SetValue(&root, 40, "ints", "[]")
+	require.NoError(t, err)
+
+	err = SetValue(&root, 35, "ints", "[-1]")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 35}, *ints)
+
+	err = SetValue(&root, 1, "ints", "[-5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValue(&root, 45, "ints", "[4]")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "fourth_client", "clients", "[2]", "name")
+	require.NoError(t, err)
+
+	ints, err = GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 35, 45}, *ints)
+
+	name, err := GetValue[string](&root, "clients", "[2]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "fourth_client", *name)
+
+	err = SetValue(&root, 1, "ints", "[6]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValue(&root, "third_client", "clients", "[]", "name")
+	require.NoError(t, err)
+
+	name, err = GetValue[string](&root, "clients", "[-1]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "third_client", *name)
+
+	err = SetValue(&root, 1, "servers", "server1", "host", "name")
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+
+	err = SetValue(&root, 1, "servers", "[]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = SetValue(&rootEmpty, "Matus", "Company", "CEO", "Name")
+	require.NoError(t, err)
+
+	ceo, err := GetValue[string](&rootEmpty, "Company", "CEO", "Name")
+	require.NoError(t, err)
+	require.Equal(t, "Matus", *ceo)
+}
+
+func TestGetValueEmptyMap(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("empty_section: {}\nnull_section:\n"), &root)
+	require.NoError(t, err)
+
+	section, err := GetValue[map[string]int](&root, "empty_section")
+	require.NoError(t, err)
+	require.NotNil(t, *section)
+	(*section)["port"] = 9001
+
+	section, err = GetValue[map[string]int](&root, "null_section")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{}, *section)
+
+	list, err := GetValue[[]int](&root, "null_section")
+	require.NoError(t, err)
+	require.Equal(t, []int{}, *list)
+}
+
+func TestGetValueOr(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	port := GetValueOr(&root, 8080, "servers", "server1", "port")
+	require.Equal(t, 9001, port)
+
+	port = GetValueOr(&root, 8080, "servers", "server3", "port")
+	require.Equal(t, 8080, port)
+
+	name := GetValueOr(&root, "nobody", "clients", "[5]", "name")
+	require.Equal(t, "nobody", name)
+
+	port, err = GetValueOrErr(&root, 8080, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, 8080, port)
+
+	port, err = GetValueOrErr(&root, 8080, "servers", "server1", "host")
+	require.Error(t, err)
+	require.Equal(t, 8080, port)
+
+	port, err = GetValueOrErr(&root, 8080, "servers", "server1", "host", "port")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Equal(t, 8080, port)
+
+	port, err = GetValueOrErr(nil, 8080, "servers")
+	require.Equal(t, ErrRootNodeNotSet, err)
+	require.Equal(t, 8080, port)
+}
+
+func TestGetValueWildcard(t *testing.T) {
+	var root yaml.Node
+	var rootList yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(listYAML), &rootList)
+	require.NoError(t, err)
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "second_client"}, *names)
+
+	ints, err := GetValue[[]int](&rootList, "[*]")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20}, *ints)
+
+	names, err = GetValue[[]string](&root, "clients", "[*]", "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Nil(t, names)
+
+	names, err = GetValue[[]string](&root, "servers", "[*]", "host")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Nil(t, names)
+
+	err = SetValue(&root, 1, "ints", "[*]")
+	require.ErrorIs(t, err, ErrInvalidIndexFormat)
+}
+
+func TestGetValueMappingWildcard(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"literal:\n  \"{*}\": star\n"), &root)
+	require.NoError(t, err)
+
+	hosts, err := GetValue[[]string](&root, "servers", "{*}", "host")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1.local", "server2.local"}, *hosts)
+
+	ports, err := GetValue[[]int](&root, "servers", "{*}", "port")
+	require.NoError(t, err)
+	require.Equal(t, []int{9001, 9002}, *ports)
+
+	names, err := GetValue[[][]string](&root, "{*}", "[*]", "name")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Nil(t, names)
+
+	_, err = GetValue[[]string](&root, "servers", "{*}", "unknown")
+	require.EqualError(t, err, "key not found at servers.server1.unknown (line 9, col 5)")
+
+	_, err = GetValue[[]int](&root, "ints", "{*}")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
 
+	star, err := GetValue[string](&root, "literal", `\{*}`)
+	require.NoError(t, err)
+	require.Equal(t, "star", *star)
+
+	path, err := ParsePath("servers.{*}.host")
+	require.NoError(t, err)
+
+	hosts, err = GetValue[[]string](&root, path...)
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1.local", "server2.local"}, *hosts)
+
+	err = SetValue(&root, 1, "servers", "{*}", "port")
+	require.ErrorIs(t, err, ErrInvalidKeysList)
+
+	err = SetValue(&root, "new", "literal", `\{*}`)
+	require.NoError(t, err)
+}
+
+func TestSetValueKindMismatch(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValue(&root, "x", "clients", "first", "name")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.EqualError(t, err, "unexpected node kind provided: sequence found, index expected instead of key first at clients.first (line 3, col 3)")
+
+	err = SetValue(&root, "x", "servers", "[]", "host")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.EqualError(t, err, `unexpected node kind provided: mapping found, key expected instead of index [] (literal key has to be escaped as \[]) at servers.[] (line 8, col 3)`)
+
+	err = SetValue(&root, "x", "servers", "[1]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = SetValue(&root, "x", "clients", "[0]", "[0]")
+	require.EqualError(t, err, `unexpected node kind provided: mapping found, key expected instead of index [0] (literal key has to be escaped as \[0]) at clients.[0].[0] (line 3, col 5)`)
+
+	// failed set does not create anything
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "second_client"}, *names)
+
+	_, err = GetValue[string](&root, "clients", "first", "name")
+	require.EqualError(t, err, "unexpected node kind provided: sequence found, index expected instead of key first at clients.first (line 3, col 3)")
+
+	err = DeleteValue(&root, "clients", "first")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+}
+
+func TestSetValueCreatesIndex(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValue(&root, "c", "new_list", "[2]")
+	require.NoError(t, err)
+
+	list, err := GetValue[[]any](&root, "new_list")
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, nil, "c"}, *list)
+
+	err = SetValue(&root, "server3.local", "servers", "server3", "hosts", "[1]", "name")
+	require.NoError(t, err)
+
+	name, err := GetValue[string](&root, "servers", "server3", "hosts", "[1]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "server3.local", *name)
+
+	out, err := GetValueBytes(&root, "servers", "server3")
+	require.NoError(t, err)
+	require.Equal(t, "hosts:\n    - null\n    - name: server3.local\n", string(out))
+
+	// existing sequence is not padded, only index equal to its length appends
+	err = SetValue(&root, 50, "ints", "[5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValue(&root, 1, "servers", "[0]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = SetValue(&root, 1, "servers", `\[0]`)
+	require.NoError(t, err)
+
+	var rootEmpty yaml.Node
+	err = SetValue(&rootEmpty, "b", "[1]")
+	require.NoError(t, err)
+
+	list, err = GetValue[[]any](&rootEmpty)
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, "b"}, *list)
+}
+
+func TestSetValueAppendThenDescend(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	// [] appends new item to missing or existing sequence, [-1] adds fields to the last item
+	err = SetValue(&root, "web", "services", "[]", "name")
+	require.NoError(t, err)
+
+	err = SetValue(&root, 8080, "services", "[-1]", "port")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "db", "services", "[]", "name")
+	require.NoError(t, err)
+
+	err = SetValue(&root, 5432, "services", "[-1]", "port")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "primary", "services", "[-1]", "roles", "[]")
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "services")
+	require.NoError(t, err)
+	require.Equal(t, "- name: web\n  port: 8080\n- name: db\n  port: 5432\n  roles:\n    - primary\n", string(out))
+
+	err = SetValue(&root, "third_surname", "clients", "[-1]", "surname")
+	require.NoError(t, err)
+
+	surnames, err := GetValue[[]string](&root, "clients", "[*]", "surname")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_surname", "third_surname"}, *surnames)
+
+	// [-1] never creates item, there is nothing to descend into
+	err = SetValue(&root, 1, "missing", "[-1]", "port")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+	require.EqualError(t, err, "provided index out of bound: [-1] refers to existing item, but the sequence does not exist yet at missing.[-1]")
+	require.False(t, Exists(&root, "missing"))
+
+	err = SetValue(&root, 1, "services", "[]", "ports", "[-1]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValue(&root, []int{}, "empty")
+	require.NoError(t, err)
+
+	err = SetValue(&root, 1, "empty", "[-1]", "port")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValue(&root, 1, "servers", "[-1]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+}
+
+func TestSetValueDocumentRoot(t *testing.T) {
+	var root yaml.Node
+
+	type server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	}
+
+	err := SetValue(&root, server{Host: "server1.local", Port: 9001})
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "host: server1.local\nport: 9001\n", string(out))
+
+	err = SetValue(&root, []int{10, 20})
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root)
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20}, *ints)
+
+	mapping, err := resolveNode(&root)
+	require.NoError(t, err)
+
+	// only document content can be replaced without keys
+	err = SetValue(mapping, 1)
+	require.Equal(t, ErrInvalidKeysList, err)
+
+	err = SetValue(nil, 1)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestSetValueTag(t *testing.T) {
+	var root yaml.Node
+
+	err := SetValueTag(&root, "true", "!!str", "feature", "flag")
+	require.NoError(t, err)
+
+	err = SetValueTag(&root, "aGVsbG8=", "!!binary", "payload")
+	require.NoError(t, err)
+
+	err = SetValueTag(&root, "db-password", "!secret", "password")
+	require.NoError(t, err)
+
+	err = SetValueTag(&root, "0x10", "!!int", "mask")
+	require.NoError(t, err)
+
+	flag, err := GetValue[any](&root, "feature", "flag")
+	require.NoError(t, err)
+	require.Equal(t, "true", *flag)
+
+	payload, err := GetValue[string](&root, "payload")
+	require.NoError(t, err)
+	require.Equal(t, "hello", *payload)
+
+	mask, err := GetValue[int](&root, "mask")
+	require.NoError(t, err)
+	require.Equal(t, 16, *mask)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "feature:\n  flag: \"true\"\npayload: !!binary aGVsbG8=\npassword: !secret db-password\nmask: 0x10\n", string(out))
+
+	err = SetValueTag(nil, "x", "!!str", "a")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestSetNull(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetNull(&root, "servers", "server1", "port")
+	require.NoError(t, err)
+
+	err = SetNull(&root, "servers", "server3", "port")
+	require.NoError(t, err)
+
+	err = SetNull(&root, "ints", "[0]")
+	require.NoError(t, err)
+
+	require.True(t, Exists(&root, "servers", "server1", "port"))
+
+	port, err := GetValue[*int](&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Nil(t, *port)
+
+	ints, err := GetValue[[]any](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, 20, 30}, *ints)
+
+	out, err := GetValueBytes(&root, "servers")
+	require.NoError(t, err)
+	require.Equal(t, "server1:\n    host: server1.local\n    port: null\nserver2:\n    host: server2.local\n    port: 9002\nserver3:\n    port: null\n", string(out))
+
+	err = SetNull(&root, "servers", "server1", "host", "name")
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+
+	err = SetNull(nil, "servers")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestSetValues(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValues(&root, []string{"servers", "server3"}, map[string]any{"port": 9003, "host": "server3.local", "tags": []string{"new"}})
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "servers", "server3")
+	require.NoError(t, err)
+	require.Equal(t, []string{"host", "port", "tags"}, keys)
+
+	port, err := GetValue[int](&root, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9003, *port)
+
+	err = SetValues(&root, nil, map[string]any{"version": 2})
+	require.NoError(t, err)
+
+	version, err := GetValue[int](&root, "version")
+	require.NoError(t, err)
+	require.Equal(t, 2, *version)
+
+	err = SetValues(&root, []string{"servers", "server1", "host"}, map[string]any{"a": 1, "b": 2})
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+
+	err = SetValues(nil, []string{"servers"}, map[string]any{"a": 1})
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestSetValueStrict(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValueStrict(&root, 9101, "servers", "server1", "port")
+	require.NoError(t, err)
+
+	err = SetValueStrict(&root, "admin", "servers", "server1", "user")
+	require.NoError(t, err)
+
+	err = SetValueStrict(&root, 40, "ints", "[]")
+	require.NoError(t, err)
+
+	err = SetValueStrict(&root, 50, "ints", "[4]")
+	require.NoError(t, err)
+
+	err = SetValueStrict(&root, "third_client", "clients", "[-1]", "name")
+	require.NoError(t, err)
+
+	server1, err := GetValue[map[string]any](&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"host": "server1.local", "port": 9101, "user": "admin"}, *server1)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 40, 50}, *ints)
+
+	err = SetValueStrict(&root, 9003, "severs", "server1", "port")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.EqualError(t, err, "key not found at severs")
+	require.False(t, Exists(&root, "severs"))
+
+	err = SetValueStrict(&root, 9003, "servers", "server3", "port")
+	require.EqualError(t, err, "key not found at servers.server3")
+
+	err = SetValueStrict(&root, "x", "clients", "[]", "name")
+	require.EqualError(t, err, "key not found at clients.[]")
+
+	err = SetValueStrict(&root, "x", "clients", "[2]", "name")
+	require.EqualError(t, err, "key not found at clients.[2]")
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client", "third_client"}, *names)
+
+	var rootEmpty yaml.Node
+	err = SetValueStrict(&rootEmpty, 1, "a", "b")
+	require.EqualError(t, err, "key not found at a")
+
+	err = SetValueStrict(&rootEmpty, 1, "a")
+	require.NoError(t, err)
+
+	err = SetValueStrict(nil, 1, "a")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestSetValueIfAbsent(t *testing.T) {
+	var root yaml.Node
+	var rootEmpty yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	set, err := SetValueIfAbsent(&root, 8080, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.False(t, set)
+
+	port, err := GetValue[int](&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9001, *port)
+
+	set, err = SetValueIfAbsent(&root, 8080, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.True(t, set)
+
+	port, err = GetValue[int](&root, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, 8080, *port)
+
+	set, err = SetValueIfAbsent(&root, 8080, "servers", "server1", "host", "port")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.False(t, set)
+
+	set, err = SetValueIfAbsent(&rootEmpty, "x", "name")
+	require.NoError(t, err)
+	require.True(t, set)
+
+	set, err = SetValueIfAbsent(&rootEmpty, "y", "name")
+	require.NoError(t, err)
+	require.False(t, set)
+
+	name, err := GetValue[string](&rootEmpty, "name")
+	require.NoError(t, err)
+	require.Equal(t, "x", *name)
+}
+
+func TestGetOrCreate(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	server1, err := GetOrCreate(&root, "servers", "server1")
+	require.NoError(t, err)
+
+	_, expected, err := getEntry(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Same(t, expected, server1)
+
+	server3, err := GetOrCreate(&root, "servers", "server3")
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, server3.Kind)
+
+	err = SetValue(server3, "server3.local", "host")
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "servers", "server3", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server3.local", *host)
+
+	limits, err := GetOrCreate(&root, "servers", "server3", "limits", "memory")
+	require.NoError(t, err)
+
+	err = SetValue(limits, "512Mi", "max")
+	require.NoError(t, err)
+
+	service, err := GetOrCreate(&root, "services", "[]")
+	require.NoError(t, err)
+
+	err = SetValue(service, "web", "name")
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "servers", "server3")
+	require.NoError(t, err)
+	require.Equal(t, "host: server3.local\nlimits:\n    memory:\n        max: 512Mi\n", string(out))
+
+	names, err := GetValue[[]string](&root, "services", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, *names)
+
+	// existing node of any kind is returned
+	ints, err := GetOrCreate(&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, yaml.SequenceNode, ints.Kind)
+
+	_, err = GetOrCreate(&root, "servers", "server1", "host", "name")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	var rootEmpty yaml.Node
+	node, err := GetOrCreate(&rootEmpty)
+	require.NoError(t, err)
+
+	err = SetValue(node, 1, "a")
+	require.NoError(t, err)
+
+	out, err = Marshal(&rootEmpty)
+	require.NoError(t, err)
+	require.Equal(t, "a: 1\n", string(out))
+
+	_, err = GetOrCreate(nil, "a")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestTouch(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	before, err := Marshal(&root)
+	require.NoError(t, err)
+
+	err = Touch(&root, "servers", "server1")
+	require.NoError(t, err)
+
+	after, err := Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+
+	err = Touch(&root, "servers", "server3", "limits")
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "servers", "server3")
+	require.NoError(t, err)
+	require.Equal(t, "limits: {}\n", string(out))
+
+	err = Touch(&root, "servers", "server3", "limits")
+	require.NoError(t, err)
+
+	err = Touch(&root, "ints")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.EqualError(t, err, "unexpected node kind provided at ints (line 15, col 3)")
+
+	err = Touch(&root, "servers", "server1", "host", "name")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = Touch(nil, "a")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestModifyMultiNodePath(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	before, err := Marshal(&root)
+	require.NoError(t, err)
+
+	for _, keys := range [][]string{
+		{"clients", "[*]"},
+		{"clients", "[0:1]"},
+		{"clients", "[?name == first_client]"},
+		{"servers", "{*}"},
+	} {
+		_, err := GetOrCreate(&root, keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		err = Touch(&root, keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		err = SetComment(&root, "comment", LineComment, keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		err = SetValueMerge(&root, map[string]string{"name": "x"}, keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		err = SortKeys(&root, keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		err = SetFlowStyle(&root, true, keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		_, err = AppendUnique(&root, "x", keys...)
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+
+		err = MoveValue(&root, keys, []string{"moved"})
+		require.ErrorIs(t, err, ErrInvalidKeysList, keys)
+	}
+
+	_, err = GetOrCreate(&root, "clients", "[*]", "name")
+	require.EqualError(t, err, "invalid keys list: [*] selects several nodes, it cannot be modified at clients.[*]")
+
+	after, err := Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+
+	// escaped keys are literal mapping keys
+	err = Touch(&root, "servers", `\{*}`)
+	require.NoError(t, err)
+	require.True(t, Exists(&root, "servers", `\{*}`))
+}
+
+func TestSetValueNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	node, err := SetValueNode(&root, 9003, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, "9003", node.Value)
+
+	node.LineComment = "# new server"
+	comment, err := GetComment(&root, LineComment, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, "# new server", comment)
+
+	node, err = SetValueNode(&root, map[string]string{"name": "third_client"}, "clients", "[]")
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, node.Kind)
+
+	last, err := resolveNode(&root, "clients", "[-1]")
+	require.NoError(t, err)
+	require.Same(t, node, last)
+
+	node, err = SetValueNode(&root, 1, "servers", "server1", "host", "name")
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+	require.Nil(t, node)
+}
+
+func TestPathError(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	_, err = GetValue[string](&root, "servers", "server3", "host")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.EqualError(t, err, "key not found at servers.server3 (line 8, col 3)")
+
+	var pathErr *PathError
+	require.True(t, errors.As(err, &pathErr))
+	require.Equal(t, []string{"servers", "server3"}, pathErr.Path)
+	require.Equal(t, 8, pathErr.Line)
+	require.Equal(t, 3, pathErr.Column)
+
+	_, err = GetValue[string](&root, "clients", "[5]", "name")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+	require.EqualError(t, err, "provided index out of bound at clients.[5] (line 3, col 3)")
+
+	_, err = GetValue[[]string](&root, "clients", "[*]", "age")
+	require.EqualError(t, err, "key not found at clients.[0].age (line 3, col 5)")
+
+	_, err = GetValue[string](&root, "servers", "server1", "host", "name")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.EqualError(t, err, "unexpected node kind provided at servers.server1.host.name (line 9, col 11)")
+
+	err = SetValue(&root, 1, "servers", "server1", "host", "name", "first")
+	require.ErrorIs(t, err, ErrScalarSetAttempt)
+	require.EqualError(t, err, "cannot set under scalar at servers.server1.host (line 9, col 11)")
+
+	err = SetValue(&root, 1, "ints", "[0]", "[]")
+	require.EqualError(t, err, "cannot set under scalar at ints.[0] (line 15, col 5)")
+
+	err = SetValue(&root, 1, "ints", "[x]")
+	require.ErrorIs(t, err, ErrInvalidIndexFormat)
+	require.EqualError(t, err, "invalid index format: expected [n] or [-n] where n is decimal number without + sign, spaces or leading zeros at ints.[x] (line 15, col 3)")
+
+	err = DeleteValue(&root, "servers", "server1", "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.EqualError(t, err, "key not found at servers.server1.unknown (line 9, col 5)")
+
+	// nodes created by SetValue have no source position
+	err = SetValue(&root, map[string]int{"port": 9003}, "servers", "server3")
+	require.NoError(t, err)
+
+	_, err = GetValue[string](&root, "servers", "server3", "host")
+	require.EqualError(t, err, "key not found at servers.server3.host")
+}
+
+const indexLikeKeysYAML = `
+releases:
+  "[0]": first
+  "[*]": all
+  draft: false
+`
+
+func TestEscapedKeys(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(indexLikeKeysYAML), &root)
+	require.NoError(t, err)
+
+	first, err := GetValue[string](&root, "releases", `\[0]`)
+	require.NoError(t, err)
+	require.Equal(t, "first", *first)
+
+	all, err := GetValue[string](&root, "releases", `\[*]`)
+	require.NoError(t, err)
+	require.Equal(t, "all", *all)
+
+	_, err = GetValue[string](&root, "releases", "[*]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = SetValue(&root, "appended", "releases", `\[]`)
+	require.NoError(t, err)
+
+	err = SetValue(&root, "nested", "new", `\[]`, "value")
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "releases")
+	require.NoError(t, err)
+	require.Equal(t, []string{"[0]", "[*]", "draft", "[]"}, keys)
+
+	keys, err = Keys(&root, "new")
+	require.NoError(t, err)
+	require.Equal(t, []string{"[]"}, keys)
+
+	err = DeleteValue(&root, "releases", `\[0]`)
+	require.NoError(t, err)
+	require.False(t, Exists(&root, "releases", `\[0]`))
+
+	path, err := ParsePath(`releases.\[*]`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"releases", `\[*]`}, path)
+
+	all, err = GetValue[string](&root, path...)
+	require.NoError(t, err)
+	require.Equal(t, "all", *all)
+}
+
+const bracketKeysYAML = `
+meta:
+  "[draft]": true
+  "[1:2]": range
+list:
+  - "[draft]"
+  - published
+`
+
+func TestEscapedKeysConsistency(t *testing.T) {
+	load := func() *yaml.Node {
+		var root yaml.Node
+		err := yaml.Unmarshal([]byte(bracketKeysYAML), &root)
+		require.NoError(t, err)
+		return &root
+	}
+
+	for _, key := range []string{`\[draft]`, `\[1:2]`} {
+		root := load()
+
+		require.True(t, Exists(root, "meta", key), key)
+
+		matches, err := GetAll(root, "{*}", key)
+		require.NoError(t, err)
+		require.Len(t, matches, 1, key)
+		require.Equal(t, []string{"meta", key}, matches[0].Path)
+
+		path, err := ParsePath("meta." + key)
+		require.NoError(t, err)
+		require.Equal(t, []string{"meta", key}, path)
+
+		require.NoError(t, SetValue(root, "changed", "meta", key))
+		require.NoError(t, SetComment(root, "literal key", LineComment, "meta", key))
+		require.NoError(t, CopyValue(root, []string{"meta", key}, []string{"copy", key}))
+		require.NoError(t, ReplaceValueNode(root, &yaml.Node{Kind: yaml.ScalarNode, Value: "replaced"}, "copy", key))
+		require.NoError(t, RenameKey(root, "renamed", "copy", key))
+		require.NoError(t, DeleteValue(root, "meta", key))
+		require.False(t, Exists(root, "meta", key))
+
+		// escaped key is never index of sequence
+		_, err = GetValue[string](root, "list", key)
+		require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+		require.ErrorIs(t, SetValue(root, "x", "list", key), ErrUnexpectedNodeKind)
+		require.ErrorIs(t, DeleteValue(root, "list", key), ErrUnexpectedNodeKind)
+	}
+
+	// unescaped key is index syntax, it never matches literal key of mapping
+	root := load()
+	for _, key := range []string{"[1:2]", "[0]", "[]", "[*]", "[?a == b]"} {
+		_, err := GetValue[string](root, "meta", key)
+		require.ErrorIs(t, err, ErrUnexpectedNodeKind, key)
+
+		err = SetValue(root, "x", "meta", key)
+		require.ErrorIs(t, err, ErrUnexpectedNodeKind, key)
+
+		err = DeleteValue(root, "meta", key)
+		require.ErrorIs(t, err, ErrUnexpectedNodeKind, key)
+
+		err = RenameKey(root, "renamed", "meta", key)
+		require.ErrorIs(t, err, ErrUnexpectedNodeKind, key)
+	}
+
+	_, err := GetValue[string](root, "meta", "[1:2]")
+	require.EqualError(t, err, `unexpected node kind provided: mapping found, key expected instead of index [1:2] (literal key has to be escaped as \[1:2]) at meta.[1:2] (line 3, col 3)`)
+
+	keys, err := Keys(root, "meta")
+	require.NoError(t, err)
+	require.Equal(t, []string{"[draft]", "[1:2]"}, keys)
+
+	// bracket key which is not valid index syntax is literal key of mapping
+	draft, err := GetValue[bool](root, "meta", "[draft]")
+	require.NoError(t, err)
+	require.True(t, *draft)
+
+	require.NoError(t, SetValue(root, false, "meta", "[draft]"))
+	require.NoError(t, RenameKey(root, "[published]", "meta", "[draft]"))
+	require.NoError(t, DeleteValue(root, "meta", "[published]"))
+	require.False(t, Exists(root, "meta", `\[published]`))
+
+	item, err := GetValue[string](root, "list", "[0]")
+	require.NoError(t, err)
+	require.Equal(t, "[draft]", *item)
+}
+
+const typedKeysYAML = `
+flags:
+  true: bool
+  "true": string
+  1: int
+  "2": string
+  0x10: hex
+  1.5: float
+  ~: nothing
+`
+
+func TestTypedKeys(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(typedKeysYAML), &root)
+	require.NoError(t, err)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"true", "bool"},
+		{"True", "bool"},
+		{`\true`, "string"},
+		{"1", "int"},
+		{"2", "string"},
+		{"16", "hex"},
+		{"0x10", "hex"},
+		{"1.5", "float"},
+		{"null", "nothing"},
+		{"~", "nothing"},
+	}
+	for _, test := range tests {
+		value, err := GetValue[string](&root, "flags", test.key)
+		require.NoError(t, err, test.key)
+		require.Equal(t, test.expected, *value, test.key)
+	}
+
+	for _, key := range []string{`\1`, `\null`, "1.50x", "yes"} {
+		require.False(t, Exists(&root, "flags", key), key)
+	}
+
+	err = SetValue(&root, "changed", "flags", "16")
+	require.NoError(t, err)
+
+	// string key is created, typed key does not match
+	err = SetValue(&root, "created", "flags", `\1.5`)
+	require.NoError(t, err)
+
+	err = RenameKey(&root, "enabled", "flags", "true")
+	require.NoError(t, err)
+
+	err = DeleteValue(&root, "flags", "~")
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "flags")
+	require.NoError(t, err)
+	require.Equal(t, "enabled: bool\n\"true\": string\n1: int\n\"2\": string\n0x10: changed\n1.5: float\n\"1.5\": created\n", string(out))
+}
+func TestGetNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	server1, err := GetNode(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, server1.Kind)
+
+	err = SetValue(server1, 9101, "port")
+	require.NoError(t, err)
+
+	port, err := GetValue[int](&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9101, *port)
+
+	node, err := GetNode(&root)
+	require.NoError(t, err)
+	require.Same(t, &root, node)
+
+	node, err = GetNode(&root, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Nil(t, node)
+
+	_, err = GetNode(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestGetNodes(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`base: &base
+  name: base
+items:
+  - 1
+  - text
+  - name: first
+  - [a, b]
+  - *base
+`), &root)
+	require.NoError(t, err)
+
+	items, err := GetNodes(&root, "items")
+	require.NoError(t, err)
+	require.Len(t, items, 5)
+
+	kinds := make([]yaml.Kind, 0, len(items))
+	for _, item := range items {
+		kinds = append(kinds, item.Kind)
+	}
+	require.Equal(t, []yaml.Kind{yaml.ScalarNode, yaml.ScalarNode, yaml.MappingNode, yaml.SequenceNode, yaml.MappingNode}, kinds)
+
+	name, err := GetValueAtNode[string](items[4], "name")
+	require.NoError(t, err)
+	require.Equal(t, "base", *name)
+
+	// items are part of the tree
+	err = SetValue(items[2], "changed", "name")
+	require.NoError(t, err)
+
+	name, err = GetValue[string](&root, "items", "[2]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "changed", *name)
+
+	_, err = GetNodes(&root, "base")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	_, err = GetNodes(&root, "missing")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = GetNodes(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestGetValueInto(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	type server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	}
+	type config struct {
+		Name    string
+		Primary server
+	}
+
+	cfg := config{Name: "prod"}
+	err = GetValueInto(&root, &cfg.Primary, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, config{Name: "prod", Primary: server{Host: "server1.local", Port: 9001}}, cfg)
+
+	ints := make([]int, 0, 8)
+	err = GetValueInto(&root, &ints, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30}, ints)
+
+	err = GetValueInto(&root, &cfg.Primary, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	err = GetValueInto(&root, cfg, "servers", "server1")
+	require.Equal(t, ErrInvalidOutput, err)
+
+	var nilServer *server
+	err = GetValueInto(&root, nilServer, "servers", "server1")
+	require.Equal(t, ErrInvalidOutput, err)
+
+	err = GetValueInto(&root, nil, "servers", "server1")
+	require.Equal(t, ErrInvalidOutput, err)
+
+	err = GetValueInto(nil, &cfg)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestGetValueAtNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	servers, err := resolveNode(&root, "servers")
+	require.NoError(t, err)
+
+	port, err := GetValueAtNode[int](servers, "server2", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9002, *port)
+
+	clients, err := resolveNode(&root, "clients")
+	require.NoError(t, err)
+
+	name, err := GetValueAtNode[string](clients, "[-1]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "second_client", *name)
+
+	host, err := GetValueAtNode[string](servers.Content[1], "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	_, err = GetValueAtNode[string](servers, "servers")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = GetValueAtNode[string](nil, "servers")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestRangeIndex(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints", "[1:3]")
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 30}, *ints)
+
+	ints, err = GetValue[[]int](&root, "ints", "[:2]")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20}, *ints)
+
+	ints, err = GetValue[[]int](&root, "ints", "[2:]")
+	require.NoError(t, err)
+	require.Equal(t, []int{30}, *ints)
+
+	ints, err = GetValue[[]int](&root, "ints", "[-2:]")
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 30}, *ints)
+
+	ints, err = GetValue[[]int](&root, "ints", "[1:1]")
+	require.NoError(t, err)
+	require.Equal(t, []int{}, *ints)
+
+	names, err := GetValue[[]string](&root, "clients", "[1:]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second_client"}, *names)
+
+	_, err = GetValue[[]int](&root, "ints", "[2:1]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	_, err = GetValue[[]int](&root, "ints", "[0:4]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	_, err = GetValue[[]int](&root, "ints", "[a:]")
+	require.ErrorIs(t, err, ErrInvalidIndexFormat)
+
+	err = DeleteValue(&root, "clients", "[0:]", "name")
+	require.ErrorIs(t, err, ErrInvalidKeysList)
+
+	err = DeleteValue(&root, "ints", "[:2]")
+	require.NoError(t, err)
+
+	ints, err = GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{30}, *ints)
+
+	err = DeleteValue(&root, "ints", "[0:5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+}
+
+func TestIndexFormat(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	for _, index := range []string{"[+1]", "[ 1 ]", "[1 ]", "[01]", "[-01]", "[-]", "[1.0]", "[0x1]", "[]"} {
+		_, err = GetValue[int](&root, "ints", index)
+		require.ErrorIs(t, err, ErrInvalidIndexFormat, index)
+
+		err = DeleteValue(&root, "ints", index)
+		require.ErrorIs(t, err, ErrInvalidIndexFormat, index)
+	}
+
+	for _, index := range []string{"[+0:]", "[:02]", "[ 1:2]"} {
+		_, err = GetValue[[]int](&root, "ints", index)
+		require.ErrorIs(t, err, ErrInvalidIndexFormat, index)
+	}
+
+	err = SetValue(&root, 1, "ints", "[+1]")
+	require.ErrorIs(t, err, ErrInvalidIndexFormat)
+
+	err = SetValue(&root, 1, "ints", "[+3]")
+	require.ErrorIs(t, err, ErrInvalidIndexFormat)
+
+	value, err := GetValue[int](&root, "ints", "[0]")
+	require.NoError(t, err)
+	require.Equal(t, 10, *value)
+
+	value, err = GetValue[int](&root, "ints", "[-3]")
+	require.NoError(t, err)
+	require.Equal(t, 10, *value)
+
+	ints, err := GetValue[[]int](&root, "ints", "[-2:]")
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 30}, *ints)
+
+	// [] appends on set
+	err = SetValue(&root, 40, "ints", "[]")
+	require.NoError(t, err)
+}
+
+func TestGetValueContract(t *testing.T) {
+	var rootZero yaml.Node
+	rootDocument := yaml.Node{Kind: yaml.DocumentNode}
+	rootMapping := yaml.Node{Kind: yaml.MappingNode}
+
+	for _, root := range []*yaml.Node{&rootZero, &rootDocument, &rootMapping, nil} {
+		for _, keys := range [][]string{nil, {"a"}, {"[0]"}, {"a", "b"}} {
+			value, err := GetValue[int](root, keys...)
+			require.True(t, (value == nil) != (err == nil), "root %v, keys %v", root, keys)
+		}
+	}
+
+	value, err := GetValue[int](&rootZero, "a")
+	require.ErrorIs(t, err, ErrEmptyDocumentNode)
+	require.Nil(t, value)
+
+	value, err = GetValue[int](&rootDocument)
+	require.NoError(t, err)
+	require.Equal(t, 0, *value)
+}
+
+func TestGetValueOrPath(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"defaults:\n  port: 8080\n  host: default.local\n"), &root)
+	require.NoError(t, err)
+
+	port, err := GetValueOrPath[int](&root, []string{"servers", "server1", "port"}, []string{"defaults", "port"})
+	require.NoError(t, err)
+	require.Equal(t, 9001, *port)
+
+	port, err = GetValueOrPath[int](&root, []string{"servers", "server3", "port"}, []string{"defaults", "port"})
+	require.NoError(t, err)
+	require.Equal(t, 8080, *port)
+
+	port, err = GetValueOrPath[int](&root, []string{"ints", "[5]"}, []string{"defaults", "port"})
+	require.NoError(t, err)
+	require.Equal(t, 8080, *port)
+
+	// decode error of primary value is not hidden by fallback
+	port, err = GetValueOrPath[int](&root, []string{"servers", "server1", "host"}, []string{"defaults", "port"})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrKeyNotFound)
+	require.Nil(t, port)
+
+	port, err = GetValueOrPath[int](&root, []string{"servers", "server3", "port"}, []string{"defaults", "timeout"})
+	require.EqualError(t, err, "key not found at defaults.timeout (line 19, col 3)")
+	require.Nil(t, port)
+}
+
+func TestMustGetValue(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	require.Equal(t, 9001, MustGetValue[int](&root, "servers", "server1", "port"))
+	require.Equal(t, []int{10, 20, 30}, MustGetValue[[]int](&root, "ints"))
+
+	require.PanicsWithError(t, "key not found at servers.server3 (line 8, col 3)", func() {
+		MustGetValue[int](&root, "servers", "server3", "port")
+	})
+}
+
+func TestTypedGetters(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"enabled: true\nratio: 0.5\n"), &root)
+	require.NoError(t, err)
+
+	host, err := GetString(&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", host)
+
+	port, err := GetInt(&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9001, port)
+
+	enabled, err := GetBool(&root, "enabled")
+	require.NoError(t, err)
+	require.True(t, enabled)
+
+	ratio, err := GetFloat64(&root, "ratio")
+	require.NoError(t, err)
+	require.Equal(t, 0.5, ratio)
+
+	host, err = GetString(&root, "servers", "server3", "host")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, "", host)
+
+	port, err = GetInt(&root, "servers", "server1", "host")
+	require.Error(t, err)
+	require.Equal(t, 0, port)
+
+	enabled, err = GetBool(&root, "ints", "[5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+	require.False(t, enabled)
+}
+
+func TestGetTagged(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"answer: 'yes'\nversion: \"10\"\nratio: 0.5\nempty:\ntoken: !secret abc\n"), &root)
+	require.NoError(t, err)
+
+	tests := []struct {
+		keys  []string
+		value any
+		tag   string
+	}{
+		{[]string{"servers", "server1", "port"}, 9001, "!!int"},
+		{[]string{"servers", "server1", "host"}, "server1.local", "!!str"},
+		{[]string{"answer"}, "yes", "!!str"},
+		{[]string{"version"}, "10", "!!str"},
+		{[]string{"ratio"}, 0.5, "!!float"},
+		{[]string{"empty"}, nil, "!!null"},
+		{[]string{"token"}, "abc", "!secret"},
+		{[]string{"ints"}, []any{10, 20, 30}, "!!seq"},
+		{[]string{"servers", "server2"}, map[string]any{"host": "server2.local", "port": 9002}, "!!map"},
+	}
+
+	for _, test := range tests {
+		value, tag, err := GetTagged(&root, test.keys...)
+		require.NoError(t, err)
+		require.Equal(t, test.value, value, test.keys)
+		require.Equal(t, test.tag, tag, test.keys)
+	}
+
+	value, tag, err := GetTagged(&root, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Nil(t, value)
+	require.Equal(t, "", tag)
+
+	_, _, err = GetTagged(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+const styledYAML = `answer: 'yes' # keep quoted
+description: |
+  first line
+port: 80
+list:
+  - "a"
+`
+
+func TestSetValuePreservesStyle(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(styledYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValue(&root, "no", "answer")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "second line\n", "description")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "b", "list", "[0]")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "eighty", "port")
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "answer: 'no' # keep quoted\ndescription: |\n  second line\nport: eighty\nlist:\n  - \"b\"\n", string(out))
+}
+
+func TestSetValueRaw(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(styledYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValueRaw(&root, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!secret", Value: "db-password"}, "database", "password")
+	require.NoError(t, err)
+
+	err = SetValueRaw(&root, &yaml.Node{Kind: yaml.ScalarNode, Value: "maybe", Style: yaml.DoubleQuotedStyle}, "answer")
+	require.NoError(t, err)
+
+	anchored := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "8080", Anchor: "port"}
+	err = SetValueRaw(&root, anchored, "port")
+	require.NoError(t, err)
+
+	err = SetValueRaw(&root, &yaml.Node{Kind: yaml.AliasNode, Value: "port", Alias: anchored}, "list", "[]")
+	require.NoError(t, err)
+
+	port, err := GetValue[int](&root, "list", "[-1]")
+	require.NoError(t, err)
+	require.Equal(t, 8080, *port)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "answer: \"maybe\"\ndescription: |\n  first line\nport: &port 8080\nlist:\n  - \"a\"\n  - *port\ndatabase:\n  password: !secret db-password\n", string(out))
+
+	err = SetValueRaw(&root, &yaml.Node{Kind: yaml.ScalarNode, Value: "x"})
+	require.Equal(t, ErrInvalidKeysList, err)
+
+	err = SetValueRaw(nil, &yaml.Node{Kind: yaml.ScalarNode, Value: "x"}, "x")
+	require.Equal(t, ErrRootNodeNotSet, err)
+
+	err = SetValueRaw(&root, nil, "database", "password")
+	require.Equal(t, ErrNilNode, err)
+
+	password, err := GetValue[string](&root, "database", "password")
+	require.NoError(t, err)
+	require.Equal(t, "db-password", *password)
 }
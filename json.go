@@ -0,0 +1,173 @@
+package gyml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToJSON serializes node into JSON, mapping keys keep their document order
+// Merge keys (<<) are expanded as decoding does, keys of the mapping itself override merged ones and come first
+// Examples:
+// ToJSON(&root) - {"clients":[{"name":"first_client",...}],...}
+func ToJSON(node *yaml.Node) ([]byte, error) {
+	if node == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	var buffer bytes.Buffer
+	if err := writeJSON(&buffer, node); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// FromJSON parses JSON into document node usable with GetValue, SetValue, DeleteValue...
+// Object keys keep their order, integer numbers are tagged !!int and other numbers !!float
+func FromJSON(data []byte) (*yaml.Node, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	node, err := readJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("cannot parse JSON: unexpected data after top-level value")
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}, nil
+}
+
+func writeJSON(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buffer.WriteString("null")
+			return nil
+		}
+		return writeJSON(buffer, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buffer, node.Alias)
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := writeJSON(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	case yaml.MappingNode:
+		buffer.WriteByte('{')
+		for i, entry := range mappingEntries(node) {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			key, err := marshalJSON(entry[0].Value)
+			if err != nil {
+				return fmt.Errorf("cannot encode JSON key: %w", err)
+			}
+			buffer.Write(key)
+			buffer.WriteByte(':')
+			if err := writeJSON(buffer, entry[1]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return nil
+	case yaml.ScalarNode:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return fmt.Errorf("cannot decode yaml node value: %w", err)
+		}
+		out, err := marshalJSON(value)
+		if err != nil {
+			return fmt.Errorf("cannot encode JSON value: %w", err)
+		}
+		buffer.Write(out)
+		return nil
+	case 0:
+		buffer.WriteString("null")
+		return nil
+	}
+	return fmt.Errorf("%w: kind %d", ErrUnexpectedNodeKind, node.Kind)
+}
+
+// marshalJSON works as json.Marshal, but characters like <, > and & are not escaped
+func marshalJSON(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+func readJSON(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse JSON: %w", err)
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '{' {
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{}}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, fmt.Errorf("cannot parse JSON: %w", err)
+				}
+				item, err := readJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyToken.(string)}
+				node.Content = append(node.Content, key, item)
+			}
+			// closing '}'
+			if _, err := decoder.Token(); err != nil {
+				return nil, fmt.Errorf("cannot parse JSON: %w", err)
+			}
+			return node, nil
+		}
+
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{}}
+		for decoder.More() {
+			item, err := readJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		// closing ']'
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("cannot parse JSON: %w", err)
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(value.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value.String()}, nil
+	case bool:
+		if value {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}, nil
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
@@ -0,0 +1,96 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSON(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	out, err := ToJSON(&root)
+	require.NoError(t, err)
+	require.Equal(t, `{"clients":[{"name":"first_client","surname":"first_surname"},{"name":"second_client","surname":"second_surname"}],`+
+		`"servers":{"server1":{"host":"server1.local","port":9001},"server2":{"host":"server2.local","port":9002}},"ints":[10,20,30]}`, string(out))
+
+	servers, err := resolveNode(&root, "servers", "server1")
+	require.NoError(t, err)
+
+	out, err = ToJSON(servers)
+	require.NoError(t, err)
+	require.Equal(t, `{"host":"server1.local","port":9001}`, string(out))
+
+	err = yaml.Unmarshal([]byte("a: &x 1.5\nb: *x\nc: null\nd: 'yes'\n"), &root)
+	require.NoError(t, err)
+
+	out, err = ToJSON(&root)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1.5,"b":1.5,"c":null,"d":"yes"}`, string(out))
+
+	// merge keys are expanded, local keys override merged ones
+	err = yaml.Unmarshal([]byte("base: &b {host: a.local, port: 1}\nprod: {<<: *b, port: 2, <tag>: a&b}\n"), &root)
+	require.NoError(t, err)
+
+	prod, err := resolveNode(&root, "prod")
+	require.NoError(t, err)
+
+	out, err = ToJSON(prod)
+	require.NoError(t, err)
+	require.Equal(t, `{"port":2,"<tag>":"a&b","host":"a.local"}`, string(out))
+
+	var decoded any
+	err = prod.Decode(&decoded)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"host": "a.local", "port": 2, "<tag>": "a&b"}, decoded)
+
+	_, err = ToJSON(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestFromJSON(t *testing.T) {
+	root, err := FromJSON([]byte(`{"name": "web", "replicas": 2, "ratio": 0.5, "big": 1e3, "tags": ["a", true, null], "meta": {}}`))
+	require.NoError(t, err)
+
+	replicas, err := GetValue[any](root, "replicas")
+	require.NoError(t, err)
+	require.Equal(t, 2, *replicas)
+
+	ratio, err := GetValue[any](root, "ratio")
+	require.NoError(t, err)
+	require.Equal(t, 0.5, *ratio)
+
+	big, err := GetValue[any](root, "big")
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, *big)
+
+	tags, err := GetValue[[]any](root, "tags")
+	require.NoError(t, err)
+	require.Equal(t, []any{"a", true, nil}, *tags)
+
+	err = SetValue(root, 3, "replicas")
+	require.NoError(t, err)
+
+	keys, err := Keys(root)
+	require.NoError(t, err)
+	require.Equal(t, []string{"name", "replicas", "ratio", "big", "tags", "meta"}, keys)
+
+	out, err := Marshal(root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "name: web\nreplicas: 3\nratio: 0.5\nbig: 1e3\ntags:\n  - a\n  - true\n  - null\nmeta: {}\n", string(out))
+
+	out, err = ToJSON(root)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"web","replicas":3,"ratio":0.5,"big":1000,"tags":["a",true,null],"meta":{}}`, string(out))
+
+	_, err = FromJSON([]byte(`{"a": 1} {"b": 2}`))
+	require.Error(t, err)
+
+	_, err = FromJSON([]byte(`{"a": `))
+	require.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package gyml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MapToNode encodes map into mapping node, which can be e.g. passed to SetValueRaw or Merge
+// Examples:
+// MapToNode(map[string]any{"host": "localhost", "port": 5432}) - mapping node with host and port keys
+func MapToNode(m map[string]any) (*yaml.Node, error) {
+	return createContentNode(m)
+}
+
+// NodeToMap decodes mapping node into generic map, document node and alias are resolved,
+// empty mapping decodes into empty non-nil map as in GetValue
+// Examples:
+// NodeToMap(&root) - whole document as map[string]any
+func NodeToMap(node *yaml.Node) (map[string]any, error) {
+	node, err := resolveNode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: mapping node expected", ErrUnexpectedNodeKind)
+	}
+
+	var result map[string]any
+	if err := decodeNode(node, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapToNode(t *testing.T) {
+	node, err := MapToNode(map[string]any{"host": "localhost", "port": 5432, "tags": []string{"db"}})
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, node.Kind)
+
+	var root yaml.Node
+	err = yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValueRaw(&root, node, "database")
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "database")
+	require.NoError(t, err)
+	require.Equal(t, "host: localhost\nport: 5432\ntags:\n    - db\n", string(out))
+
+	node, err = MapToNode(nil)
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, node.Kind)
+	require.Empty(t, node.Content)
+}
+
+func TestNodeToMap(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"empty: {}\n"), &root)
+	require.NoError(t, err)
+
+	m, err := NodeToMap(&root)
+	require.NoError(t, err)
+	require.Equal(t, []any{10, 20, 30}, m["ints"])
+	require.Equal(t, map[string]any{}, m["empty"])
+
+	server, err := GetNode(&root, "servers", "server1")
+	require.NoError(t, err)
+
+	m, err = NodeToMap(server)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"host": "server1.local", "port": 9001}, m)
+
+	empty, err := GetNode(&root, "empty")
+	require.NoError(t, err)
+
+	m, err = NodeToMap(empty)
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	require.Empty(t, m)
+
+	ints, err := GetNode(&root, "ints")
+	require.NoError(t, err)
+
+	_, err = NodeToMap(ints)
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	_, err = NodeToMap(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
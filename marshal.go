@@ -0,0 +1,133 @@
+package gyml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalOption configures serialization done by Marshal, SaveFile...
+type MarshalOption func(*marshalOptions)
+
+type marshalOptions struct {
+	indent int
+}
+
+// Indent sets number of spaces used for indentation, yaml.v3 default is 4, negative n makes marshaling fail by ErrInvalidIndent
+func Indent(n int) MarshalOption {
+	return func(o *marshalOptions) {
+		o.indent = n
+	}
+}
+
+// Marshal serializes root node into yaml
+// Examples:
+// Marshal(&root, Indent(2)) - yaml with 2 spaces indentation
+func Marshal(root *yaml.Node, opts ...MarshalOption) ([]byte, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	var buffer bytes.Buffer
+	encoder, err := newEncoder(&buffer, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("cannot encode yaml node: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("cannot encode yaml node: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// MarshalCanonical serializes root node into deterministic form for minimal diffs, e.g. of config stored in git,
+// mapping keys are sorted recursively, indentation is 2 spaces and styles are reset, so flow collections
+// become block ones and scalars are quoted only when needed, comments are kept, root itself is not modified
+// Strings which YAML 1.1 parsers read as other type when plain (yes, off, 1:20...) are always double quoted
+// Anchors are moved before their aliases when sorting changed their order
+// Examples:
+// MarshalCanonical(&root) - same output for documents which differ only in key order or formatting
+func MarshalCanonical(root *yaml.Node) ([]byte, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	canonical := CloneNode(root)
+	visitNodes(canonical, func(node *yaml.Node) {
+		node.Style = 0
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" && isYAML11Ambiguous(node.Value) {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		if node.Kind == yaml.MappingNode {
+			sortMappingKeys(node)
+		}
+	})
+	orderAnchors(canonical, map[*yaml.Node]bool{})
+
+	return Marshal(canonical, Indent(2))
+}
+
+// orderAnchors makes anchored nodes precede their aliases in document order, anchored node is moved
+// to the position of its first alias and alias is placed to its original position instead
+func orderAnchors(parent *yaml.Node, seen map[*yaml.Node]bool) {
+	for i, child := range parent.Content {
+		if child.Kind == yaml.AliasNode && child.Alias != nil && !seen[child.Alias] {
+			child = child.Alias
+			parent.Content[i] = child
+		} else if child.Anchor != "" && seen[child] {
+			parent.Content[i] = &yaml.Node{Kind: yaml.AliasNode, Value: child.Anchor, Alias: child}
+			continue
+		}
+
+		if child.Anchor != "" {
+			seen[child] = true
+		}
+		orderAnchors(child, seen)
+	}
+}
+
+func newEncoder(w io.Writer, opts ...MarshalOption) (*yaml.Encoder, error) {
+	options := marshalOptions{indent: 4}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// yaml.v3 panics on negative indent
+	if options.indent < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidIndent, options.indent)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(options.indent)
+	return encoder, nil
+}
+
+// GetValueBytes returns yaml of the subtree on the path, comments and styles of the subtree are kept
+// Examples:
+// GetValueBytes(&root, "servers", "server1") - "host: server1.local\nport: 9001\n"
+func GetValueBytes(root *yaml.Node, keys ...string) ([]byte, error) {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return Marshal(node)
+}
+
+var base60Float = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?$`)
+
+// isYAML11Ambiguous reports whether plain scalar value is resolved as bool or float by YAML 1.1 parsers,
+// yaml.v3 resolves such values as strings, so it does not quote them when their node has no style
+func isYAML11Ambiguous(value string) bool {
+	switch value {
+	case "y", "Y", "yes", "Yes", "YES", "n", "N", "no", "No", "NO", "on", "On", "ON", "off", "Off", "OFF":
+		return true
+	}
+	return base60Float.MatchString(value)
+}
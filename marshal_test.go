@@ -0,0 +1,184 @@
+package gyml
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	var root yaml.Node
+
+	err := SetValue(&root, "server1.local", "servers", "server1", "host")
+	require.NoError(t, err)
+
+	err = SetValue(&root, 10, "servers", "server1", "ports", "[]")
+	require.NoError(t, err)
+
+	out, err := Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, "servers:\n    server1:\n        host: server1.local\n        ports:\n            - 10\n", string(out))
+
+	out, err = Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "servers:\n  server1:\n    host: server1.local\n    ports:\n      - 10\n", string(out))
+
+	out, err = Marshal(&root, Indent(-1))
+	require.ErrorIs(t, err, ErrInvalidIndent)
+	require.Nil(t, out)
+
+	_, err = MarshalDocuments([]*yaml.Node{&root}, Indent(-2))
+	require.ErrorIs(t, err, ErrInvalidIndent)
+
+	err = SaveFile(filepath.Join(t.TempDir(), "config.yaml"), &root, 0o644, Indent(-1))
+	require.ErrorIs(t, err, ErrInvalidIndent)
+
+	out, err = Marshal(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+	require.Nil(t, out)
+}
+
+func TestGetValueBytes(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"# answer comment\nanswer: 'yes' # keep quoted\n"), &root)
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, "host: server1.local\nport: 9001\n", string(out))
+
+	out, err = GetValueBytes(&root, "answer")
+	require.NoError(t, err)
+	require.Equal(t, "'yes' # keep quoted\n", string(out))
+
+	out, err = GetValueBytes(&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, "- 10\n- 20\n- 30\n", string(out))
+
+	_, err = GetValueBytes(&root, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	var first yaml.Node
+	var second yaml.Node
+
+	err := yaml.Unmarshal([]byte(`# servers config
+servers:
+    server2: {port: 9002, host: "server2.local"}
+    server1:
+        port: 9001 # default
+        host: 'server1.local'
+ints: [10, 20]
+version: "10"
+description: |
+    multi
+    line
+`), &first)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(`description: "multi\nline\n"
+ints:
+  - 10
+  - 20
+# servers config
+servers:
+  server1:
+    host: server1.local
+    port: 9001 # default
+  server2:
+    host: server2.local
+    port: 9002
+version: '10'
+`), &second)
+	require.NoError(t, err)
+
+	// comments stay with their keys
+	expected := `description: |
+  multi
+  line
+ints:
+  - 10
+  - 20
+# servers config
+servers:
+  server1:
+    host: server1.local
+    port: 9001 # default
+  server2:
+    host: server2.local
+    port: 9002
+version: "10"
+`
+
+	out, err := MarshalCanonical(&first)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(out))
+
+	out, err = MarshalCanonical(&second)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(out))
+
+	// root is not modified
+	keys, err := Keys(&first)
+	require.NoError(t, err)
+	require.Equal(t, []string{"servers", "ints", "version", "description"}, keys)
+
+	_, err = MarshalCanonical(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestMarshalCanonicalAmbiguousStrings(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`a: 'yes'
+b: on-call
+c: 'off'
+d: "1:20"
+e: N
+f: plain
+`), &root)
+	require.NoError(t, err)
+
+	out, err := MarshalCanonical(&root)
+	require.NoError(t, err)
+	require.Equal(t, `a: "yes"
+b: on-call
+c: "off"
+d: "1:20"
+e: "N"
+f: plain
+`, string(out))
+}
+
+func TestMarshalCanonicalAnchors(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`zone: &zone eu
+servers:
+  b: &base
+    host: base.local
+  a: *base
+region: *zone
+`), &root)
+	require.NoError(t, err)
+
+	out, err := MarshalCanonical(&root)
+	require.NoError(t, err)
+	require.Equal(t, `region: &zone eu
+servers:
+  a: &base
+    host: base.local
+  b: *base
+zone: *zone
+`, string(out))
+
+	var reparsed yaml.Node
+	err = yaml.Unmarshal(out, &reparsed)
+	require.NoError(t, err)
+	require.True(t, Equal(&root, &reparsed))
+}
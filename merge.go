@@ -0,0 +1,154 @@
+package gyml
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// MergeMode defines how sequences present in both trees are merged
+type MergeMode int
+
+const (
+	// sequence from src replaces sequence in dst
+	SeqReplace MergeMode = iota
+	// items of src sequence are appended to dst sequence
+	SeqAppend
+	// only items of src sequence which are not present in dst sequence are appended (compared by serialized value)
+	SeqUniqueAppend
+)
+
+// Merge deep merges src tree into dst tree
+// Mapping nodes are merged key by key (src wins on conflict), sequences and scalars from src replace the ones in dst
+// Nodes of dst which are not present in src are kept untouched including their comments and styles
+// Examples:
+// Merge(&base, &override) - apply environment specific override on top of base config
+func Merge(dst, src *yaml.Node) error {
+	return MergeWith(dst, src, SeqReplace)
+}
+
+// MergeWith works as Merge, sequences present in both trees are merged according to mode
+// Examples:
+// MergeWith(&base, &override, SeqAppend) - list fields of override extend list fields of base
+func MergeWith(dst, src *yaml.Node, mode MergeMode) error {
+	if dst == nil || src == nil {
+		return ErrRootNodeNotSet
+	}
+
+	srcNode := src
+	if src.Kind == yaml.DocumentNode {
+		if len(src.Content) == 0 {
+			return nil
+		}
+		srcNode = src.Content[0]
+	}
+	if srcNode.Kind == 0 {
+		return nil
+	}
+
+	// zero node (e.g. unmarshaled empty input) is handled as an empty document
+	if dst.Kind == 0 {
+		dst.Kind = yaml.DocumentNode
+	}
+
+	dstNode := dst
+	if dst.Kind == yaml.DocumentNode {
+		if len(dst.Content) == 0 {
+			dst.Content = append(dst.Content, CloneNode(srcNode))
+			return nil
+		}
+		dstNode = dst.Content[0]
+	}
+
+	merged, err := mergeNodes(dstNode, srcNode, mode)
+	if err != nil {
+		return err
+	}
+	if merged != dstNode {
+		*dstNode = *merged
+	}
+	return nil
+}
+
+// SetValueMerge encodes data and deep merges it into existing node on the path as Merge does, so keys of mapping
+// which are not present in data are kept, missing path is created in the same way as SetValue does
+// Zero fields of struct are encoded too, so use omitempty tag for fields which should not overwrite existing values
+// Examples:
+// SetValueMerge(&root, map[string]any{"port": 9101}, "servers", "server1") - change port, keep host of server1
+func SetValueMerge[DataType any](root *yaml.Node, data DataType, keys ...string) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	value, err := createContentNode(data)
+	if err != nil {
+		return err
+	}
+
+	node, created, err := resolveOrCreate(root, value, keys...)
+	if err != nil || created {
+		return err
+	}
+	return Merge(node, value)
+}
+
+// mergeNodes merges src into dst and returns node which should be placed on dst position
+func mergeNodes(dst, src *yaml.Node, mode MergeMode) (*yaml.Node, error) {
+	if dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode {
+		return mergeSequences(dst, src, mode)
+	}
+
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return CloneNode(src), nil
+	}
+
+	for i := 0; i < len(src.Content); i += 2 {
+		found := false
+		for j := 0; j < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == src.Content[i].Value {
+				merged, err := mergeNodes(dst.Content[j+1], src.Content[i+1], mode)
+				if err != nil {
+					return nil, err
+				}
+				dst.Content[j+1] = merged
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			dst.Content = append(dst.Content, CloneNode(src.Content[i]), CloneNode(src.Content[i+1]))
+		}
+	}
+	return dst, nil
+}
+
+func mergeSequences(dst, src *yaml.Node, mode MergeMode) (*yaml.Node, error) {
+	switch mode {
+	case SeqAppend:
+		for _, item := range src.Content {
+			dst.Content = append(dst.Content, CloneNode(item))
+		}
+		return dst, nil
+	case SeqUniqueAppend:
+		present := make(map[string]bool, len(dst.Content))
+		for _, item := range dst.Content {
+			value, err := serializedValue(item)
+			if err != nil {
+				return nil, err
+			}
+			present[value] = true
+		}
+
+		for _, item := range src.Content {
+			value, err := serializedValue(item)
+			if err != nil {
+				return nil, err
+			}
+			if !present[value] {
+				dst.Content = append(dst.Content, CloneNode(item))
+				present[value] = true
+			}
+		}
+		return dst, nil
+	}
+	return CloneNode(src), nil
+}
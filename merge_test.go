@@ -0,0 +1,181 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+const overrideYAML = `
+servers:
+  server1:
+    port: 9101
+  server3:
+    host: server3.local
+ints:
+  - 40
+`
+
+func TestMerge(t *testing.T) {
+	var root yaml.Node
+	var override yaml.Node
+	var rootEmpty yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(overrideYAML), &override)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(emptyYAML), &rootEmpty)
+	require.NoError(t, err)
+
+	err = Merge(&root, &override)
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", *host)
+
+	port, err := GetValue[int](&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9101, *port)
+
+	host, err = GetValue[string](&root, "servers", "server3", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server3.local", *host)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{40}, *ints)
+
+	keys, err := Keys(&root, "servers")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1", "server2", "server3"}, keys)
+
+	// merged nodes are not shared with src
+	err = SetValue(&override, "changed.local", "servers", "server3", "host")
+	require.NoError(t, err)
+
+	host, err = GetValue[string](&root, "servers", "server3", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server3.local", *host)
+
+	err = Merge(&rootEmpty, &root)
+	require.NoError(t, err)
+
+	port, err = GetValue[int](&rootEmpty, "servers", "server2", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9002, *port)
+
+	err = Merge(nil, &root)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestMergeWith(t *testing.T) {
+	var override yaml.Node
+
+	err := yaml.Unmarshal([]byte(`{ints: [30, 40, 40], clients: [{name: first_client, surname: first_surname}]}`), &override)
+	require.NoError(t, err)
+
+	var root yaml.Node
+	err = yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = MergeWith(&root, &override, SeqReplace)
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{30, 40, 40}, *ints)
+
+	root = yaml.Node{}
+	err = yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = MergeWith(&root, &override, SeqAppend)
+	require.NoError(t, err)
+
+	ints, err = GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 30, 40, 40}, *ints)
+
+	length, err := Len(&root, "clients")
+	require.NoError(t, err)
+	require.Equal(t, 3, length)
+
+	root = yaml.Node{}
+	err = yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = MergeWith(&root, &override, SeqUniqueAppend)
+	require.NoError(t, err)
+
+	ints, err = GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 40}, *ints)
+
+	length, err = Len(&root, "clients")
+	require.NoError(t, err)
+	require.Equal(t, 2, length)
+}
+
+func TestSetValueMerge(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	type server struct {
+		Host string `yaml:"host,omitempty"`
+		Port int    `yaml:"port,omitempty"`
+		User string `yaml:"user,omitempty"`
+	}
+
+	err = SetValueMerge(&root, server{Port: 9101, User: "admin"}, "servers", "server1")
+	require.NoError(t, err)
+
+	server1, err := GetValue[server](&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, server{Host: "server1.local", Port: 9101, User: "admin"}, *server1)
+
+	err = SetValueMerge(&root, map[string]any{"server2": map[string]any{"host": "db.local"}}, "servers")
+	require.NoError(t, err)
+
+	server2, err := GetValue[server](&root, "servers", "server2")
+	require.NoError(t, err)
+	require.Equal(t, server{Host: "db.local", Port: 9002}, *server2)
+
+	err = SetValueMerge(&root, server{Host: "server3.local"}, "servers", "server3")
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "servers")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1", "server2", "server3"}, keys)
+
+	err = SetValueMerge(&root, []int{40}, "ints")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{40}, *ints)
+
+	var rootEmpty yaml.Node
+	err = SetValueMerge(&rootEmpty, map[string]int{"port": 80})
+	require.NoError(t, err)
+
+	err = SetValueMerge(&rootEmpty, map[string]string{"host": "localhost"})
+	require.NoError(t, err)
+
+	out, err := Marshal(&rootEmpty)
+	require.NoError(t, err)
+	require.Equal(t, "port: 80\nhost: localhost\n", string(out))
+
+	err = SetValueMerge(&root, 1, "ints", "[5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+
+	err = SetValueMerge(nil, 1)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
@@ -0,0 +1,274 @@
+package gyml
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Navigator resolves paths in yaml tree according to its options,
+// package level functions (GetValue, SetValue, DeleteValue...) use navigator with default options
+type Navigator struct {
+	stopAtAliases    bool
+	keepEmptyParents bool
+	caseInsensitive  bool
+	noCreateParents  bool
+	keyTransform     func(string) string
+}
+
+// NavigatorOption configures Navigator created by NewNavigator
+type NavigatorOption func(*Navigator)
+
+var defaultNavigator = &Navigator{}
+
+// NewNavigator creates navigator with provided options
+// Examples:
+// NewNavigator(WithStopAtAliases()).Set(&root, "x", "prod", "host") - fails instead of changing host of aliased anchor
+func NewNavigator(opts ...NavigatorOption) *Navigator {
+	n := &Navigator{}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// WithStopAtAliases disables following of alias nodes, navigation through alias returns ErrAliasTraversal
+// By default aliases are resolved, so reads return the anchored content and writes through alias modify the anchored node
+func WithStopAtAliases() NavigatorOption {
+	return func(n *Navigator) {
+		n.stopAtAliases = true
+	}
+}
+
+// WithKeepEmptyParents disables removal of mappings and sequences which became empty after Delete,
+// e.g. deleting the last server keeps empty "servers: {}" in the document
+func WithKeepEmptyParents() NavigatorOption {
+	return func(n *Navigator) {
+		n.keepEmptyParents = true
+	}
+}
+
+// WithNoCreateParents disables creation of missing intermediate nodes in Set, only the last key of the path can be missing,
+// otherwise ErrKeyNotFound is returned with path of the first missing key, so typo in the path does not create new branch
+func WithNoCreateParents() NavigatorOption {
+	return func(n *Navigator) {
+		n.noCreateParents = true
+	}
+}
+
+// WithKeyTransform names struct fields without yaml tag name in the value encoded by Set by transform of Go field name
+// instead of yaml.v3 lowercased field name, e.g. to store DBHost as db_host without yaml tags on every field
+// Fields with yaml tag name, keys of maps and of the path and existing nodes are not changed
+// Examples:
+// NewNavigator(WithKeyTransform(toSnakeCase)).Set(&root, cfg, "database") - DBHost field is stored as db_host
+func WithKeyTransform(transform func(string) string) NavigatorOption {
+	return func(n *Navigator) {
+		n.keyTransform = transform
+	}
+}
+
+// WithCaseInsensitive makes mapping keys match regardless of case, e.g. "Servers" matches "servers"
+// Set reuses casing of the existing key instead of adding another key which differs in case only
+func WithCaseInsensitive() NavigatorOption {
+	return func(n *Navigator) {
+		n.caseInsensitive = true
+	}
+}
+
+// GetNode returns node on the path
+func (n *Navigator) GetNode(root *yaml.Node, keys ...string) (*yaml.Node, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+	return n.getValueFrom(root, nil, keys...)
+}
+
+// Get decodes value on the path into out, which has to be a pointer
+func (n *Navigator) Get(root *yaml.Node, out any, keys ...string) error {
+	node, err := n.GetNode(root, keys...)
+	if err != nil {
+		return err
+	}
+	return decodeNode(node, out)
+}
+
+// decodeNode decodes node into out, nil slice or map is replaced by empty one
+func decodeNode(node *yaml.Node, out any) error {
+	if err := node.Decode(out); err != nil {
+		return fmt.Errorf("cannot decode yaml node value: %w", err)
+	}
+	normalizeEmptySlice(out)
+	normalizeEmptyMap(out)
+	return nil
+}
+
+// Set works as SetValue
+func (n *Navigator) Set(root *yaml.Node, data any, keys ...string) error {
+	_, err := n.setValue(root, data, keys...)
+	return err
+}
+
+// Delete works as DeleteValue
+func (n *Navigator) Delete(root *yaml.Node, keys ...string) error {
+	if len(keys) == 0 {
+		return ErrInvalidKeysList
+	}
+
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+	return n.deleteValueFrom(root, nil, keys...)
+}
+
+func (n *Navigator) setValue(root *yaml.Node, data any, keys ...string) (*yaml.Node, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	value, err := createContentNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.keyTransform != nil {
+		transformFieldKeys(value, reflect.ValueOf(data), n.keyTransform)
+	}
+
+	old, err := n.setNodeFrom(root, value, nil, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	if old != nil {
+		preserveScalarStyle(old, value)
+	}
+	return value, nil
+}
+
+// followAlias returns anchored node of alias node, other nodes are returned as they are
+func (n *Navigator) followAlias(node *yaml.Node, path []string) (*yaml.Node, error) {
+	if node.Kind != yaml.AliasNode {
+		return node, nil
+	}
+
+	if n.stopAtAliases || node.Alias == nil {
+		return nil, newPathError(ErrAliasTraversal, path)
+	}
+	return node.Alias, nil
+}
+
+// matchKey reports whether mapping key node matches path key, path key can be escaped
+// Non-string scalar keys (bool, int, float, null) match only unescaped path key resolving to the same tag and value,
+// e.g. path key "1" matches key 0x1, key true is matched by "true" but not by `\true`, which is string only
+// String keys are compared literally, so when mapping has both true and "true" keys, the first one matches "true"
+func (n *Navigator) matchKey(keyNode *yaml.Node, key string) bool {
+	if keyNode.Kind == yaml.ScalarNode && isTypedKeyTag(keyNode.ShortTag()) {
+		return matchTypedKey(keyNode, key)
+	}
+
+	if n.caseInsensitive {
+		return strings.EqualFold(keyNode.Value, unescapeKey(key))
+	}
+	return keyNode.Value == unescapeKey(key)
+}
+
+func isTypedKeyTag(tag string) bool {
+	switch tag {
+	case "!!bool", "!!int", "!!float", "!!null":
+		return true
+	}
+	return false
+}
+
+// matchTypedKey compares non-string key node with path key resolved as plain scalar
+func matchTypedKey(keyNode *yaml.Node, key string) bool {
+	if strings.HasPrefix(key, `\`) {
+		return false
+	}
+
+	keyScalar := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+	if keyScalar.ShortTag() != keyNode.ShortTag() {
+		return false
+	}
+
+	var nodeValue, keyValue any
+	if keyNode.Decode(&nodeValue) != nil || keyScalar.Decode(&keyValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(nodeValue, keyValue)
+}
+
+// transformFieldKeys renames mapping keys of node encoded from value, which come from struct fields without yaml tag name
+func transformFieldKeys(node *yaml.Node, value reflect.Value, transform func(string) string) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	// nodes and custom marshalers produce their own keys
+	if !value.IsValid() || value.Type() == nodeType || isMarshaler(value.Type()) {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields, inlineMap := structFields(value.Type())
+		for i := 0; i < len(node.Content); i += 2 {
+			j := slices.IndexFunc(fields, func(field structField) bool { return field.name == node.Content[i].Value })
+			if j < 0 {
+				if inlineMap != nil {
+					if m, err := value.FieldByIndexErr(inlineMap); err == nil {
+						transformMapValue(node.Content[i], node.Content[i+1], m, transform)
+					}
+				}
+				continue
+			}
+
+			field, err := value.FieldByIndexErr(fields[j].index)
+			if err != nil {
+				continue
+			}
+			if !fields[j].tagged {
+				node.Content[i].Value = transform(fields[j].goName)
+			}
+			transformFieldKeys(node.Content[i+1], field, transform)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			transformMapValue(node.Content[i], node.Content[i+1], value, transform)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode || len(node.Content) != value.Len() {
+			return
+		}
+		for i, item := range node.Content {
+			transformFieldKeys(item, value.Index(i), transform)
+		}
+	}
+}
+
+// transformMapValue transforms value of map entry with the key, the key itself is data, so it is kept
+func transformMapValue(keyNode *yaml.Node, valueNode *yaml.Node, m reflect.Value, transform func(string) string) {
+	for iter := m.MapRange(); iter.Next(); {
+		if fmt.Sprint(iter.Key().Interface()) == keyNode.Value {
+			transformFieldKeys(valueNode, iter.Value(), transform)
+			return
+		}
+	}
+}
+
+func isMarshaler(t reflect.Type) bool {
+	pointer := reflect.PointerTo(t)
+	return pointer.Implements(marshalerType) || pointer.Implements(textMarshalerType)
+}
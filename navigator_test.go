@@ -0,0 +1,200 @@
+package gyml
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+const aliasYAML = `
+base: &base
+  host: base.local
+  port: 9000
+servers:
+  server1: *base
+  server2:
+    host: server2.local
+`
+
+func TestAliasNavigation(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(aliasYAML), &root)
+	require.NoError(t, err)
+
+	host, err := GetValue[string](&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "base.local", *host)
+
+	kind, err := NodeKind(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, kind)
+
+	keys, err := Keys(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"host", "port"}, keys)
+
+	// write through alias changes anchored node
+	err = SetValue(&root, "shared.local", "servers", "server1", "host")
+	require.NoError(t, err)
+
+	host, err = GetValue[string](&root, "base", "host")
+	require.NoError(t, err)
+	require.Equal(t, "shared.local", *host)
+
+	err = DeleteValue(&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.False(t, Exists(&root, "base", "port"))
+
+	nav := NewNavigator(WithStopAtAliases())
+
+	var value string
+	err = nav.Get(&root, &value, "servers", "server1", "host")
+	require.ErrorIs(t, err, ErrAliasTraversal)
+	require.EqualError(t, err, "cannot navigate through alias node at servers.server1")
+
+	err = nav.Set(&root, "other.local", "servers", "server1", "host")
+	require.ErrorIs(t, err, ErrAliasTraversal)
+
+	err = nav.Delete(&root, "servers", "server1", "host")
+	require.ErrorIs(t, err, ErrAliasTraversal)
+
+	node, err := nav.GetNode(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, yaml.AliasNode, node.Kind)
+
+	err = nav.Get(&root, &value, "servers", "server2", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server2.local", value)
+
+	// replacing alias itself does not touch anchored node
+	err = nav.Set(&root, map[string]string{"host": "own.local"}, "servers", "server1")
+	require.NoError(t, err)
+
+	host, err = GetValue[string](&root, "base", "host")
+	require.NoError(t, err)
+	require.Equal(t, "shared.local", *host)
+
+	host, err = GetValue[string](&root, "servers", "server1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "own.local", *host)
+
+	err = nav.Delete(&root, "servers", "server2")
+	require.NoError(t, err)
+
+	_, err = nav.GetNode(nil, "servers")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestCaseInsensitiveNavigator(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("Servers:\n  Server1:\n    Host: server1.local\n"), &root)
+	require.NoError(t, err)
+
+	nav := NewNavigator(WithCaseInsensitive())
+
+	var host string
+	err = nav.Get(&root, &host, "servers", "SERVER1", "host")
+	require.NoError(t, err)
+	require.Equal(t, "server1.local", host)
+
+	err = NewNavigator().Get(&root, &host, "servers", "server1", "host")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// existing key casing is reused
+	err = nav.Set(&root, "remote.local", "servers", "server1", "host")
+	require.NoError(t, err)
+
+	err = nav.Set(&root, 9001, "servers", "server1", "port")
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "Servers:\n  Server1:\n    Host: remote.local\n    port: 9001\n", string(out))
+
+	err = nav.Delete(&root, "SERVERS", "server1", "HOST")
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "Servers", "Server1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"port"}, keys)
+}
+
+func TestKeyTransformNavigator(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	type Meta struct {
+		CreatedBy string
+	}
+	type endpoint struct {
+		HostName   string
+		PortNumber int `yaml:",omitempty"`
+	}
+	type server struct {
+		Meta      `yaml:",inline"`
+		HostName  string
+		KeepName  string `yaml:"keepName"`
+		Endpoints []endpoint
+		Labels    map[string]endpoint
+		Ignored   string `yaml:"-"`
+	}
+
+	toSnakeCase := func(key string) string {
+		var builder strings.Builder
+		for i, r := range key {
+			if unicode.IsUpper(r) {
+				if i > 0 {
+					builder.WriteByte('_')
+				}
+				r = unicode.ToLower(r)
+			}
+			builder.WriteRune(r)
+		}
+		return builder.String()
+	}
+
+	nav := NewNavigator(WithKeyTransform(toSnakeCase))
+	err = nav.Set(&root, &server{
+		Meta:      Meta{CreatedBy: "admin"},
+		HostName:  "server3.local",
+		KeepName:  "tagged",
+		Endpoints: []endpoint{{HostName: "a.local", PortNumber: 80}, {HostName: "b.local"}},
+		Labels:    map[string]endpoint{"app-Name": {HostName: "c.local"}},
+	}, "servers", "serverThree")
+	require.NoError(t, err)
+
+	out, err := GetValueBytes(&root, "servers", "serverThree")
+	require.NoError(t, err)
+	require.Equal(t, `created_by: admin
+host_name: server3.local
+keepName: tagged
+endpoints:
+    - host_name: a.local
+      port_number: 80
+    - host_name: b.local
+labels:
+    app-Name:
+        host_name: c.local
+`, string(out))
+
+	// keys of maps are data, they are not changed
+	err = nav.Set(&root, map[string]int{"maxConnections": 10}, "servers", "server1", "limits")
+	require.NoError(t, err)
+
+	limits, err := GetValue[map[string]int](&root, "servers", "server1", "limits")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"maxConnections": 10}, *limits)
+
+	// keys of the path are used as they are
+	err = nav.Set(&root, "x", "servers", "server1", "hostName")
+	require.NoError(t, err)
+	require.True(t, Exists(&root, "servers", "server1", "hostName"))
+}
@@ -0,0 +1,70 @@
+package gyml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PatchOp defines kind of the Patch operation
+type PatchOp string
+
+const (
+	// set Value on Path, as SetValue does
+	OpSet PatchOp = "set"
+	// delete Path, as DeleteValue does
+	OpDelete PatchOp = "delete"
+	// move subtree from From to Path, as MoveValue does
+	OpMove PatchOp = "move"
+	// copy subtree from From to Path, as CopyValue does
+	OpCopy PatchOp = "copy"
+)
+
+// Operation is single edit applied by Patch
+// Value is used by OpSet only, *yaml.Node value is placed as SetValueRaw does, From is used by OpMove and OpCopy
+type Operation struct {
+	Op    PatchOp
+	Path  []string
+	From  []string
+	Value any
+}
+
+// Patch applies all operations in order, either all of them succeed or root is left unchanged
+// Operations are applied on a copy of the tree which replaces root content once the whole batch succeeded
+// Examples:
+// Patch(&root, []Operation{{Op: OpMove, From: []string{"servers"}, Path: []string{"hosts"}}, {Op: OpSet, Path: []string{"version"}, Value: 2}})
+func Patch(root *yaml.Node, ops []Operation) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	patched := CloneNode(root)
+	for i, op := range ops {
+		if err := applyOperation(patched, op); err != nil {
+			return fmt.Errorf("patch operation %d (%s): %w", i, op.Op, err)
+		}
+	}
+
+	*root = *patched
+	return nil
+}
+
+func applyOperation(root *yaml.Node, op Operation) error {
+	switch op.Op {
+	case OpSet:
+		if node, ok := op.Value.(*yaml.Node); ok {
+			if node == nil {
+				return ErrNilNode
+			}
+			return SetValueRaw(root, CloneNode(node), op.Path...)
+		}
+		return SetValue(root, op.Value, op.Path...)
+	case OpDelete:
+		return DeleteValue(root, op.Path...)
+	case OpMove:
+		return MoveValue(root, op.From, op.Path)
+	case OpCopy:
+		return CopyValue(root, op.From, op.Path)
+	}
+	return ErrUnknownOperation
+}
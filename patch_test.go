@@ -0,0 +1,78 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatch(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = Patch(&root, []Operation{
+		{Op: OpSet, Path: []string{"servers", "server1", "port"}, Value: 9101},
+		{Op: OpCopy, From: []string{"servers", "server1"}, Path: []string{"servers", "server3"}},
+		{Op: OpMove, From: []string{"ints"}, Path: []string{"numbers"}},
+		{Op: OpDelete, Path: []string{"clients", "[0]"}},
+		{Op: OpSet, Path: []string{"secret"}, Value: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!secret", Value: "pwd"}},
+	})
+	require.NoError(t, err)
+
+	port, err := GetValue[int](&root, "servers", "server3", "port")
+	require.NoError(t, err)
+	require.Equal(t, 9101, *port)
+
+	numbers, err := GetValue[[]int](&root, "numbers")
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30}, *numbers)
+
+	require.False(t, Exists(&root, "ints"))
+
+	name, err := GetValue[string](&root, "clients", "[0]", "name")
+	require.NoError(t, err)
+	require.Equal(t, "second_client", *name)
+
+	secret, err := resolveNode(&root, "secret")
+	require.NoError(t, err)
+	require.Equal(t, "!secret", secret.Tag)
+}
+
+func TestPatchRollback(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	before, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+
+	err = Patch(&root, []Operation{
+		{Op: OpSet, Path: []string{"servers", "server1", "port"}, Value: 9101},
+		{Op: OpDelete, Path: []string{"ints"}},
+		{Op: OpDelete, Path: []string{"servers", "server9"}},
+	})
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.ErrorContains(t, err, "patch operation 2 (delete)")
+
+	after, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+
+	err = Patch(&root, []Operation{{Op: "replace", Path: []string{"ints"}}})
+	require.ErrorIs(t, err, ErrUnknownOperation)
+
+	err = Patch(&root, []Operation{{Op: OpSet, Path: []string{"ints"}, Value: (*yaml.Node)(nil)}})
+	require.ErrorIs(t, err, ErrNilNode)
+
+	after, err = yaml.Marshal(&root)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+
+	err = Patch(nil, nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
@@ -0,0 +1,153 @@
+package gyml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePath splits dotted path string into list of keys usable by GetValue, SetValue, DeleteValue...
+// Dot separates keys, bracket segments are kept as index keys, backslash escapes next character
+// Examples:
+// ParsePath("servers.server1.host") - ["servers", "server1", "host"]
+// ParsePath("clients[1].surname") - ["clients", "[1]", "surname"]
+// ParsePath("some_list[]") - ["some_list", "[]"]
+// ParsePath(`a\.b.c`) - ["a.b", "c"]
+// ParsePath(`\[0].c`) - [`\[0]`, "c"], key is escaped so it is handled as literal "[0]" mapping key
+// ParsePath("servers.{*}.host") - ["servers", "{*}", "host"], `\{*}` is literal "{*}" mapping key
+func ParsePath(path string) ([]string, error) {
+	keys := []string{}
+	if path == "" {
+		return keys, nil
+	}
+
+	var key strings.Builder
+	pendingKey := false // key characters collected but not yet flushed
+	escapedKey := false // key contains escaped character, so it cannot be wildcard
+	expectKey := true   // path start or dot requires following key or index
+	afterIndex := false // index segment ended, only '.', '[' or end may follow
+
+	flush := func() {
+		if !escapedKey && key.String() == wildcardKey {
+			keys = append(keys, wildcardKey)
+		} else {
+			keys = append(keys, escapeKey(key.String()))
+		}
+		key.Reset()
+		pendingKey = false
+		escapedKey = false
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\\':
+			if i+1 == len(path) {
+				return nil, fmt.Errorf("%w: trailing escape character in %q", ErrInvalidPath, path)
+			}
+			if afterIndex {
+				return nil, fmt.Errorf("%w: unexpected character after index at %d in %q", ErrInvalidPath, i, path)
+			}
+			i++
+			key.WriteByte(path[i])
+			pendingKey = true
+			escapedKey = true
+			expectKey = false
+		case '.':
+			if expectKey {
+				return nil, fmt.Errorf("%w: empty key at %d in %q", ErrInvalidPath, i, path)
+			}
+			if pendingKey {
+				flush()
+			}
+			expectKey = true
+			afterIndex = false
+		case '[':
+			if pendingKey {
+				flush()
+			}
+			end := closingBracket(path[i:])
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed bracket at %d in %q", ErrInvalidPath, i, path)
+			}
+			keys = append(keys, path[i:i+end+1])
+			i += end
+			expectKey = false
+			afterIndex = true
+		default:
+			if afterIndex {
+				return nil, fmt.Errorf("%w: unexpected character after index at %d in %q", ErrInvalidPath, i, path)
+			}
+			key.WriteByte(c)
+			pendingKey = true
+			expectKey = false
+		}
+	}
+
+	if expectKey {
+		return nil, fmt.Errorf("%w: path ends with separator %q", ErrInvalidPath, path)
+	}
+	if pendingKey {
+		flush()
+	}
+
+	return keys, nil
+}
+
+// closingBracket returns index of ']' closing bracket segment at the start of s or -1,
+// quoted values of [?...] filters are skipped, so they can contain ']'
+func closingBracket(s string) int {
+	if !strings.HasPrefix(s, "[?") {
+		return strings.IndexByte(s, ']')
+	}
+
+	var quote byte
+	for i := 2; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// escapeKey escapes mapping key which would be otherwise handled as index (e.g. "[0]" or "[]") or wildcard by leading backslash
+func escapeKey(key string) string {
+	if strings.HasPrefix(key, "[") || strings.HasPrefix(key, `\`) || key == wildcardKey {
+		return `\` + key
+	}
+	return key
+}
+
+// isIndexSyntax reports whether key is valid index, append, wildcard, range or filter key, such key never matches
+// literal mapping key, e.g. mapping key "[0]" has to be addressed by escaped `\[0]` in every function,
+// other bracket keys like "[draft]" are literal mapping keys
+func isIndexSyntax(key string) bool {
+	if key == "[]" || key == wildcardIndex || isFilterKey(key) {
+		return true
+	}
+
+	if _, ok := parseIndexKey(key); ok {
+		return true
+	}
+
+	if !isRangeIndex(key) {
+		return false
+	}
+	start, end, _ := strings.Cut(key[1:len(key)-1], ":")
+	for _, bound := range []string{start, end} {
+		if _, err := parseIndexNumber(bound); bound != "" && err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// unescapeKey returns literal mapping key for the path key, leading backslash is removed
+func unescapeKey(key string) string {
+	return strings.TrimPrefix(key, `\`)
+}
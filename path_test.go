@@ -0,0 +1,80 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	keys, err := ParsePath("servers.server1.host")
+	require.NoError(t, err)
+	require.Equal(t, []string{"servers", "server1", "host"}, keys)
+
+	keys, err = ParsePath("clients[1].surname")
+	require.NoError(t, err)
+	require.Equal(t, []string{"clients", "[1]", "surname"}, keys)
+
+	keys, err = ParsePath("matrix[0][-1]")
+	require.NoError(t, err)
+	require.Equal(t, []string{"matrix", "[0]", "[-1]"}, keys)
+
+	keys, err = ParsePath("[1].name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"[1]", "name"}, keys)
+
+	keys, err = ParsePath("some_list[]")
+	require.NoError(t, err)
+	require.Equal(t, []string{"some_list", "[]"}, keys)
+
+	keys, err = ParsePath(`items[?name == "[a]"].id[0]`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"items", `[?name == "[a]"]`, "id", "[0]"}, keys)
+
+	keys, err = ParsePath(`items[?name == 'a]b'][1]`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"items", `[?name == 'a]b']`, "[1]"}, keys)
+
+	keys, err = ParsePath(`a\.b.c\\d`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.b", `c\d`}, keys)
+
+	keys, err = ParsePath(`\[0].a\[1]`)
+	require.NoError(t, err)
+	require.Equal(t, []string{`\[0]`, "a[1]"}, keys)
+
+	keys, err = ParsePath(`\\a`)
+	require.NoError(t, err)
+	require.Equal(t, []string{`\\a`}, keys)
+
+	keys, err = ParsePath("servers.{*}.host")
+	require.NoError(t, err)
+	require.Equal(t, []string{"servers", "{*}", "host"}, keys)
+
+	keys, err = ParsePath(`servers.\{*}.host`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"servers", `\{*}`, "host"}, keys)
+
+	keys, err = ParsePath("")
+	require.NoError(t, err)
+	require.Equal(t, []string{}, keys)
+
+	for _, path := range []string{".a", "a.", "a..b", "a[1", "a[1]b", `a\`} {
+		keys, err = ParsePath(path)
+		require.ErrorIs(t, err, ErrInvalidPath, path)
+		require.Nil(t, keys)
+	}
+
+	var root yaml.Node
+	err = yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	keys, err = ParsePath("clients[1].surname")
+	require.NoError(t, err)
+
+	surname, err := GetValue[string](&root, keys...)
+	require.NoError(t, err)
+	require.Equal(t, "second_surname", *surname)
+}
@@ -0,0 +1,91 @@
+package gyml
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+var byteOrderMark = []byte("\xef\xbb\xbf")
+
+// Preamble is part of yaml input which is not kept in nodes by yaml.v3, byte order mark and directives
+// of the first document, e.g. "%YAML 1.2" or "%TAG !e! tag:example.com,2000:"
+type Preamble struct {
+	BOM        bool
+	Directives []string
+}
+
+// UnmarshalWithPreamble parses yaml data as yaml.Unmarshal does and returns its preamble, so it can be written back
+// by MarshalWithPreamble, %YAML directive is not passed to yaml.v3, which rejects other versions than 1.1
+// Examples:
+// UnmarshalWithPreamble([]byte("%YAML 1.2\n---\nport: 80\n")) - root node and Preamble{Directives: ["%YAML 1.2"]}
+func UnmarshalWithPreamble(data []byte) (*yaml.Node, Preamble, error) {
+	input, preamble := splitPreamble(data)
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(input, &root); err != nil {
+		return nil, Preamble{}, fmt.Errorf("cannot parse yaml: %w", err)
+	}
+	return &root, preamble, nil
+}
+
+// splitPreamble returns data without byte order mark and with blanked %YAML directive together with the preamble
+func splitPreamble(data []byte) ([]byte, Preamble) {
+	preamble := Preamble{}
+	if bytes.HasPrefix(data, byteOrderMark) {
+		preamble.BOM = true
+		data = data[len(byteOrderMark):]
+	}
+
+	// directives are followed by "---", only blank and comment lines can be between them
+	input := slices.Clone(data)
+	for offset := 0; offset < len(input); {
+		end := bytes.IndexByte(input[offset:], '\n')
+		if end < 0 {
+			end = len(input) - offset
+		}
+		line := bytes.TrimRight(input[offset:offset+end], " \t\r")
+
+		if bytes.HasPrefix(line, []byte("%")) {
+			preamble.Directives = append(preamble.Directives, string(line))
+			// line is blanked instead of removed, so line numbers of nodes stay the same
+			if bytes.HasPrefix(line, []byte("%YAML")) {
+				for i := offset; i < offset+len(line); i++ {
+					input[i] = ' '
+				}
+			}
+		} else if len(line) > 0 && line[0] != '#' {
+			break
+		}
+		offset += end + 1
+	}
+	return input, preamble
+}
+
+// MarshalWithPreamble serializes root node as Marshal does and puts byte order mark and directives
+// of preamble in front of it, "---" marker is added after directives
+// Examples:
+// MarshalWithPreamble(root, preamble, Indent(2)) - "%YAML 1.2\n---\nport: 80\n" for root and preamble from the example above
+func MarshalWithPreamble(root *yaml.Node, preamble Preamble, opts ...MarshalOption) ([]byte, error) {
+	data, err := Marshal(root, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if preamble.BOM {
+		buffer.Write(byteOrderMark)
+	}
+
+	if len(preamble.Directives) > 0 {
+		for _, directive := range preamble.Directives {
+			buffer.WriteString(directive + "\n")
+		}
+		buffer.WriteString("---\n")
+	}
+
+	buffer.Write(data)
+	return buffer.Bytes(), nil
+}
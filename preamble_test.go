@@ -0,0 +1,52 @@
+package gyml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreambleRoundTrip(t *testing.T) {
+	root, preamble, err := UnmarshalWithPreamble([]byte("%YAML 1.2\n---\n# servers\nservers:\n  server1:\n    port: 9001\n"))
+	require.NoError(t, err)
+	require.Equal(t, Preamble{Directives: []string{"%YAML 1.2"}}, preamble)
+
+	err = SetValue(root, 9101, "servers", "server1", "port")
+	require.NoError(t, err)
+
+	// line numbers are not shifted by removed directive
+	_, err = GetValue[string](root, "servers", "server2")
+	require.EqualError(t, err, "key not found at servers.server2 (line 5, col 3)")
+
+	out, err := MarshalWithPreamble(root, preamble, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "%YAML 1.2\n---\n# servers\nservers:\n  server1:\n    port: 9101\n", string(out))
+
+	root, preamble, err = UnmarshalWithPreamble([]byte("\ufeff# config\n%YAML 1.1\n%TAG !e! tag:example.com,2000:\n---\nvalue: !e!secret abc\n"))
+	require.NoError(t, err)
+	require.Equal(t, Preamble{BOM: true, Directives: []string{"%YAML 1.1", "%TAG !e! tag:example.com,2000:"}}, preamble)
+
+	value, tag, err := GetTagged(root, "value")
+	require.NoError(t, err)
+	require.Equal(t, "abc", value)
+	require.Equal(t, "tag:example.com,2000:secret", tag)
+
+	out, err = MarshalWithPreamble(root, preamble)
+	require.NoError(t, err)
+	require.Equal(t, "\ufeff%YAML 1.1\n%TAG !e! tag:example.com,2000:\n---\n# config\nvalue: !<tag:example.com,2000:secret> abc\n", string(out))
+
+	// yaml without preamble is written as Marshal writes it
+	root, preamble, err = UnmarshalWithPreamble([]byte("# comment\nmessage: '%s'\n"))
+	require.NoError(t, err)
+	require.Equal(t, Preamble{}, preamble)
+
+	out, err = MarshalWithPreamble(root, preamble)
+	require.NoError(t, err)
+	require.Equal(t, "# comment\nmessage: '%s'\n", string(out))
+
+	_, _, err = UnmarshalWithPreamble([]byte("a: [1"))
+	require.ErrorContains(t, err, "cannot parse yaml")
+
+	_, err = MarshalWithPreamble(nil, preamble)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
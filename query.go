@@ -0,0 +1,450 @@
+package gyml
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Exists reports whether the path defined by keys can be resolved, node on the path is not decoded
+// Examples:
+// Exists(&root, "servers", "server1", "host") - true when server1 has host property
+func Exists(root *yaml.Node, keys ...string) bool {
+	if root == nil {
+		return false
+	}
+
+	_, err := getValue(root, keys...)
+	return err == nil
+}
+
+// Keys returns keys of mapping node on the path in document order
+// Examples:
+// Keys(&root, "servers") - ["server1", "server2"]
+func Keys(root *yaml.Node, keys ...string) ([]string, error) {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: mapping node expected", ErrUnexpectedNodeKind)
+	}
+
+	result := make([]string, 0, len(node.Content)/2)
+	for i := 0; i < len(node.Content); i += 2 {
+		result = append(result, node.Content[i].Value)
+	}
+	return result, nil
+}
+
+// KeysRecursive returns path of every scalar leaf of the tree in document order, "[i]" is used for sequence items
+// Returned paths can be passed to GetValue, alias of scalar is a leaf as well, other alias nodes are not followed as in Walk
+// Examples:
+// KeysRecursive(&root) - [["clients", "[0]", "name"], ["clients", "[0]", "surname"], ..., ["ints", "[2]"]]
+func KeysRecursive(root *yaml.Node) [][]string {
+	result := [][]string{}
+	_ = Walk(root, func(path []string, node *yaml.Node) error {
+		if node.Kind == yaml.ScalarNode || (node.Kind == yaml.AliasNode && node.Alias != nil && node.Alias.Kind == yaml.ScalarNode) {
+			result = append(result, slices.Clone(path))
+		}
+		return nil
+	})
+	return result
+}
+
+// Len returns number of items in sequence node or number of key/value pairs in mapping node on the path
+// Examples:
+// Len(&root, "clients") - 2
+func Len(root *yaml.Node, keys ...string) (int, error) {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return 0, err
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return len(node.Content), nil
+	case yaml.MappingNode:
+		return len(node.Content) / 2, nil
+	}
+	return 0, fmt.Errorf("%w: sequence or mapping node expected", ErrUnexpectedNodeKind)
+}
+
+// NodeKind returns kind of the node on the path, document node is unwrapped to its content
+// Examples:
+// NodeKind(&root, "servers") - yaml.MappingNode
+// NodeKind(&root, "clients") - yaml.SequenceNode
+func NodeKind(root *yaml.Node, keys ...string) (yaml.Kind, error) {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return 0, err
+	}
+	return node.Kind, nil
+}
+
+// IsScalar reports whether node on the path is scalar, missing path is reported as error (e.g. ErrKeyNotFound)
+// Examples:
+// IsScalar(&root, "servers", "server1", "port") - true
+func IsScalar(root *yaml.Node, keys ...string) (bool, error) {
+	return isKind(root, yaml.ScalarNode, keys...)
+}
+
+// IsMapping reports whether node on the path is mapping, missing path is reported as error (e.g. ErrKeyNotFound)
+// Examples:
+// IsMapping(&root, "servers") - true
+func IsMapping(root *yaml.Node, keys ...string) (bool, error) {
+	return isKind(root, yaml.MappingNode, keys...)
+}
+
+// IsSequence reports whether node on the path is sequence, missing path is reported as error (e.g. ErrKeyNotFound)
+// Examples:
+// IsSequence(&root, "clients") - true
+func IsSequence(root *yaml.Node, keys ...string) (bool, error) {
+	return isKind(root, yaml.SequenceNode, keys...)
+}
+
+func isKind(root *yaml.Node, kind yaml.Kind, keys ...string) (bool, error) {
+	nodeKind, err := NodeKind(root, keys...)
+	if err != nil {
+		return false, err
+	}
+	return nodeKind == kind, nil
+}
+
+// ForEach calls fn for every item of sequence node on the path in order, iteration stops on the first error returned by fn
+// Examples:
+// ForEach(&root, validateClient, "clients") - call validateClient for both clients
+func ForEach(root *yaml.Node, fn func(index int, elem *yaml.Node) error, keys ...string) error {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%w: sequence node expected", ErrUnexpectedNodeKind)
+	}
+
+	for i, elem := range node.Content {
+		if err := fn(i, elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachKey calls fn for every key/value pair of mapping node on the path in document order,
+// iteration stops on the first error returned by fn
+// Examples:
+// ForEachKey(&root, checkServer, "servers") - call checkServer with "server1" and "server2" entries
+func ForEachKey(root *yaml.Node, fn func(key string, value *yaml.Node) error, keys ...string) error {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: mapping node expected", ErrUnexpectedNodeKind)
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		if err := fn(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count returns number of direct children of sequence (items) or mapping (values) node on the path for which pred returns true
+// Alias children are resolved before pred is called
+// Examples:
+// Count(&root, func(node *yaml.Node) bool { port, err := GetValueAtNode[int](node, "port"); return err == nil && *port > 9001 }, "servers") - 1
+func Count(root *yaml.Node, pred func(node *yaml.Node) bool, keys ...string) (int, error) {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return 0, err
+	}
+
+	var children []*yaml.Node
+	switch node.Kind {
+	case yaml.SequenceNode:
+		children = node.Content
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			children = append(children, node.Content[i])
+		}
+	default:
+		return 0, newNodeError(ErrUnexpectedNodeKind, keys, node)
+	}
+
+	count := 0
+	for _, child := range children {
+		if child.Kind == yaml.AliasNode {
+			child = child.Alias
+		}
+		if pred(child) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Match is node found by GetAll together with its path, path contains concrete keys instead of wildcards
+type Match struct {
+	Path []string
+	Node *yaml.Node
+}
+
+// GetAll returns every node matching the path in document order, path can contain any number of wildcards
+// "[*]" (every sequence item), "{*}" (every mapping value), ranges "[start:end]" and filters "[?field == value]"
+// Branches of wildcard expansion which do not contain rest of the path are skipped,
+// errors are returned only for the part of the path before the first wildcard
+// Examples:
+// GetAll(&root, "servers", "{*}", "host") - host of every server with paths servers.server1.host, servers.server2.host
+// GetAll(&root, "clients", "[*]", "name") - name of every client with paths clients.[0].name, clients.[1].name
+// GetAll(&root, "clients", `[?name == "first_client"]`, "surname") - surname of clients with name first_client
+func GetAll(root *yaml.Node, keys ...string) ([]Match, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	matches := []Match{}
+	if err := collectMatches(root, []string{}, keys, false, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// collectMatches resolves keys up to the first wildcard and expands the wildcard recursively,
+// expanded is set when node is reached through wildcard, then unresolvable path is skipped
+func collectMatches(node *yaml.Node, path []string, keys []string, expanded bool, matches *[]Match) error {
+	w := slices.IndexFunc(keys, func(key string) bool {
+		return key == wildcardIndex || key == wildcardKey || isRangeIndex(key) || isFilterKey(key)
+	})
+	if w < 0 {
+		w = len(keys)
+	}
+
+	skip := func(err error) error {
+		if expanded && (isMissingPath(err) || errors.Is(err, ErrUnexpectedNodeKind) || errors.Is(err, ErrEmptyDocumentNode)) {
+			return nil
+		}
+		return err
+	}
+
+	resolved, err := defaultNavigator.getValueFrom(node, path, keys[:w]...)
+	if err != nil {
+		return skip(err)
+	}
+	path = append(slices.Clip(path), keys[:w]...)
+
+	if resolved.Kind == yaml.DocumentNode {
+		if len(resolved.Content) == 0 {
+			return skip(newPathError(ErrEmptyDocumentNode, path))
+		}
+		resolved = resolved.Content[0]
+	}
+
+	if w == len(keys) {
+		*matches = append(*matches, Match{Path: path, Node: resolved})
+		return nil
+	}
+
+	rest := keys[w+1:]
+	switch {
+	case keys[w] == wildcardKey && resolved.Kind == yaml.MappingNode:
+		for i := 0; i < len(resolved.Content); i += 2 {
+			itemPath := appendPath(path, escapeKey(resolved.Content[i].Value))
+			if err := collectMatches(resolved.Content[i+1], itemPath, rest, true, matches); err != nil {
+				return err
+			}
+		}
+		return nil
+	case isFilterKey(keys[w]) && resolved.Kind == yaml.SequenceNode:
+		filter, err := parseFilter(keys[w])
+		if err != nil {
+			return newPathError(err, appendPath(path, keys[w]))
+		}
+		for i, item := range resolved.Content {
+			if !filter.match(item) {
+				continue
+			}
+			itemPath := appendPath(path, fmt.Sprintf("[%d]", i))
+			if err := collectMatches(item, itemPath, rest, true, matches); err != nil {
+				return err
+			}
+		}
+		return nil
+	case keys[w] != wildcardKey && !isFilterKey(keys[w]) && resolved.Kind == yaml.SequenceNode:
+		start, end := 0, len(resolved.Content)
+		if keys[w] != wildcardIndex {
+			start, end, err = parseRange(keys[w], resolved)
+			if err != nil {
+				return skip(newNodeError(err, appendPath(path, keys[w]), resolved))
+			}
+		}
+		for i := start; i < end; i++ {
+			itemPath := appendPath(path, fmt.Sprintf("[%d]", i))
+			if err := collectMatches(resolved.Content[i], itemPath, rest, true, matches); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return skip(newNodeError(ErrUnexpectedNodeKind, appendPath(path, keys[w]), resolved))
+}
+
+// Select returns every node matching dotted path expression, besides ParsePath syntax the expression can contain
+// filters selecting sequence items by scalar value of their field, "[?field == value]" or "[?field != value]",
+// value can be quoted by " or ', quoted value can contain "==", "!=" or "]", items without the field or with non-scalar
+// field never match
+// Nodes are returned in document order, see GetAll for handling of wildcards and filters
+// Examples:
+// Select(&root, `clients[?name == "first_client"].surname`) - surname of every client named first_client
+// Select(&root, "servers.{*}.host") - host of every server
+func Select(root *yaml.Node, expr string) ([]*yaml.Node, error) {
+	keys, err := ParsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := GetAll(root, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*yaml.Node, 0, len(matches))
+	for _, match := range matches {
+		nodes = append(nodes, match.Node)
+	}
+	return nodes, nil
+}
+
+// itemFilter selects sequence items by scalar value of their field
+type itemFilter struct {
+	field  string
+	value  string
+	negate bool
+}
+
+// isFilterKey reports whether key is [?...] filter of sequence items
+func isFilterKey(key string) bool {
+	return strings.HasPrefix(key, "[?") && strings.HasSuffix(key, "]")
+}
+
+// parseFilter parses [?field == value] and [?field != value] filters
+func parseFilter(key string) (itemFilter, error) {
+	expr := key[2 : len(key)-1]
+
+	// operator inside of quoted value is part of the value, e.g. [?name == "a!=b"]
+	operator := -1
+	var quote byte
+	for i := 0; i < len(expr) && operator < 0; i++ {
+		switch {
+		case quote != 0:
+			if expr[i] == quote {
+				quote = 0
+			}
+		case expr[i] == '"' || expr[i] == '\'':
+			quote = expr[i]
+		case strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!="):
+			operator = i
+		}
+	}
+
+	filter := itemFilter{}
+	if operator >= 0 {
+		filter.negate = expr[operator] == '!'
+		filter.field = strings.TrimSpace(expr[:operator])
+		filter.value = strings.TrimSpace(expr[operator+2:])
+	}
+	if operator < 0 || filter.field == "" || filter.value == "" {
+		return itemFilter{}, fmt.Errorf("%w: filter %s, expected [?field == value] or [?field != value]", ErrInvalidPath, key)
+	}
+
+	if len(filter.value) >= 2 && (filter.value[0] == '"' || filter.value[0] == '\'') && filter.value[len(filter.value)-1] == filter.value[0] {
+		filter.value = filter.value[1 : len(filter.value)-1]
+	}
+	return filter, nil
+}
+
+func (f itemFilter) match(item *yaml.Node) bool {
+	node, err := getValue(item, f.field)
+	if err != nil || node.Kind != yaml.ScalarNode {
+		return false
+	}
+	return (node.Value == f.value) != f.negate
+}
+
+// resolveNode returns node on the path, document node is unwrapped to its content
+func resolveNode(root *yaml.Node, keys ...string) (*yaml.Node, error) {
+	if root == nil {
+		return nil, ErrRootNodeNotSet
+	}
+
+	node, err := getValue(root, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	// zero node (e.g. unmarshaled empty input) is handled as an empty document
+	if node.Kind == 0 || (node.Kind == yaml.DocumentNode && len(node.Content) == 0) {
+		return nil, ErrEmptyDocumentNode
+	}
+
+	if node.Kind == yaml.DocumentNode {
+		return node.Content[0], nil
+	}
+	return node, nil
+}
+
+// resolveWritableNode works as resolveNode for functions modifying the node, wildcard, range and filter keys
+// are rejected, such keys collect matches into temporary sequence which is not part of the tree, so changes would be lost
+func resolveWritableNode(root *yaml.Node, keys ...string) (*yaml.Node, error) {
+	if err := checkWritablePath(keys); err != nil {
+		return nil, err
+	}
+	return resolveNode(root, keys...)
+}
+
+func checkWritablePath(keys []string) error {
+	for i, key := range keys {
+		if key == wildcardIndex || key == wildcardKey || isRangeIndex(key) || isFilterKey(key) {
+			return newPathError(fmt.Errorf("%w: %s selects several nodes, it cannot be modified", ErrInvalidKeysList, key), keys[:i+1])
+		}
+	}
+	return nil
+}
+
+// getEntry returns node on the path together with its key node when the last key addresses mapping entry,
+// keyNode is nil for sequence items and document root
+func getEntry(root *yaml.Node, keys ...string) (keyNode *yaml.Node, valueNode *yaml.Node, err error) {
+	if len(keys) == 0 {
+		valueNode, err = resolveNode(root)
+		return nil, valueNode, err
+	}
+
+	parent, err := resolveNode(root, keys[:len(keys)-1]...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if parent.Kind == yaml.MappingNode {
+		if isIndexSyntax(keys[len(keys)-1]) {
+			return nil, nil, newPathError(mappingIndexError(keys[len(keys)-1]), keys)
+		}
+
+		for i := 0; i < len(parent.Content); i += 2 {
+			if defaultNavigator.matchKey(parent.Content[i], keys[len(keys)-1]) {
+				return parent.Content[i], parent.Content[i+1], nil
+			}
+		}
+		return nil, nil, newPathError(ErrKeyNotFound, keys)
+	}
+
+	valueNode, err = getValue(parent, keys[len(keys)-1])
+	return nil, valueNode, err
+}
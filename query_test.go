@@ -0,0 +1,490 @@
+package gyml
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExists(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	require.True(t, Exists(&root, "servers", "server1", "host"))
+	require.True(t, Exists(&root, "clients", "[-1]"))
+	require.True(t, Exists(&root))
+	require.False(t, Exists(&root, "servers", "server3"))
+	require.False(t, Exists(&root, "clients", "[2]"))
+	require.False(t, Exists(&root, "servers", "server1", "host", "name"))
+	require.False(t, Exists(nil, "servers"))
+}
+
+func TestKeys(t *testing.T) {
+	var root yaml.Node
+	var rootList yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(listYAML), &rootList)
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "servers")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1", "server2"}, keys)
+
+	keys, err = Keys(&root)
+	require.NoError(t, err)
+	require.Equal(t, []string{"clients", "servers", "ints"}, keys)
+
+	keys, err = Keys(&root, "clients", "[0]")
+	require.NoError(t, err)
+	require.Equal(t, []string{"name", "surname"}, keys)
+
+	keys, err = Keys(&root, "clients")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Nil(t, keys)
+
+	keys, err = Keys(&root, "servers", "server1", "host")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Nil(t, keys)
+
+	keys, err = Keys(&root, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Nil(t, keys)
+
+	keys, err = Keys(&rootList)
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Nil(t, keys)
+
+	keys, err = Keys(nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+	require.Nil(t, keys)
+}
+
+func TestKeysRecursive(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetValue(&root, "x", "servers", "server2", `a.b`)
+	require.NoError(t, err)
+
+	err = SetValue(&root, []string{}, "empty")
+	require.NoError(t, err)
+
+	paths := KeysRecursive(&root)
+	require.Equal(t, [][]string{
+		{"clients", "[0]", "name"},
+		{"clients", "[0]", "surname"},
+		{"clients", "[1]", "name"},
+		{"clients", "[1]", "surname"},
+		{"servers", "server1", "host"},
+		{"servers", "server1", "port"},
+		{"servers", "server2", "host"},
+		{"servers", "server2", "port"},
+		{"servers", "server2", "a.b"},
+		{"ints", "[0]"},
+		{"ints", "[1]"},
+		{"ints", "[2]"},
+	}, paths)
+
+	for _, path := range paths {
+		require.True(t, Exists(&root, path...))
+	}
+
+	err = yaml.Unmarshal([]byte("x: &a 1\ny: *a\nbase: &b {h: 1}\nz: *b\nl: [*a]\n"), &root)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"x"}, {"y"}, {"base", "h"}, {"l", "[0]"}}, KeysRecursive(&root))
+
+	var rootList yaml.Node
+	err = yaml.Unmarshal([]byte("- 1\n- [2, 3]\n"), &rootList)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"[0]"}, {"[1]", "[0]"}, {"[1]", "[1]"}}, KeysRecursive(&rootList))
+
+	var rootScalar yaml.Node
+	err = yaml.Unmarshal([]byte("1"), &rootScalar)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{}}, KeysRecursive(&rootScalar))
+
+	var rootEmpty yaml.Node
+	require.Empty(t, KeysRecursive(&rootEmpty))
+	require.Empty(t, KeysRecursive(nil))
+}
+
+func TestLen(t *testing.T) {
+	var root yaml.Node
+	var rootList yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(listYAML), &rootList)
+	require.NoError(t, err)
+
+	length, err := Len(&root, "clients")
+	require.NoError(t, err)
+	require.Equal(t, 2, length)
+
+	length, err = Len(&root, "servers")
+	require.NoError(t, err)
+	require.Equal(t, 2, length)
+
+	length, err = Len(&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, 3, length)
+
+	length, err = Len(&rootList)
+	require.NoError(t, err)
+	require.Equal(t, 2, length)
+
+	length, err = Len(&root, "servers", "server1", "port")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.Equal(t, 0, length)
+
+	length, err = Len(&root, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, 0, length)
+}
+
+func TestNodeKind(t *testing.T) {
+	var root yaml.Node
+	var rootList yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = yaml.Unmarshal([]byte(listYAML), &rootList)
+	require.NoError(t, err)
+
+	kind, err := NodeKind(&root)
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, kind)
+
+	kind, err = NodeKind(&root, "clients")
+	require.NoError(t, err)
+	require.Equal(t, yaml.SequenceNode, kind)
+
+	kind, err = NodeKind(&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, yaml.ScalarNode, kind)
+
+	kind, err = NodeKind(&rootList)
+	require.NoError(t, err)
+	require.Equal(t, yaml.SequenceNode, kind)
+
+	kind, err = NodeKind(&root, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, yaml.Kind(0), kind)
+}
+
+func TestKindPredicates(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	isScalar, err := IsScalar(&root, "servers", "server1", "port")
+	require.NoError(t, err)
+	require.True(t, isScalar)
+
+	isScalar, err = IsScalar(&root, "servers")
+	require.NoError(t, err)
+	require.False(t, isScalar)
+
+	isMapping, err := IsMapping(&root, "servers")
+	require.NoError(t, err)
+	require.True(t, isMapping)
+
+	isMapping, err = IsMapping(&root, "clients")
+	require.NoError(t, err)
+	require.False(t, isMapping)
+
+	isSequence, err := IsSequence(&root, "clients")
+	require.NoError(t, err)
+	require.True(t, isSequence)
+
+	isSequence, err = IsSequence(&root, "clients", "[0]")
+	require.NoError(t, err)
+	require.False(t, isSequence)
+
+	isMapping, err = IsMapping(&root, "servers", "server3")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.False(t, isMapping)
+
+	isSequence, err = IsSequence(&root, "clients", "[5]")
+	require.ErrorIs(t, err, ErrIndexOutOfBound)
+	require.False(t, isSequence)
+}
+
+func TestForEach(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	names := []string{}
+	err = ForEach(&root, func(index int, elem *yaml.Node) error {
+		name, err := GetValue[string](elem, "name")
+		if err != nil {
+			return err
+		}
+		names = append(names, fmt.Sprintf("%s_%d", *name, index))
+		return SetValue(elem, index, "id")
+	}, "clients")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_client_0", "second_client_1"}, names)
+
+	id, err := GetValue[int](&root, "clients", "[1]", "id")
+	require.NoError(t, err)
+	require.Equal(t, 1, *id)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = ForEach(&root, func(index int, elem *yaml.Node) error {
+		calls++
+		return errStop
+	}, "ints")
+	require.Equal(t, errStop, err)
+	require.Equal(t, 1, calls)
+
+	err = ForEach(&root, func(index int, elem *yaml.Node) error { return nil }, "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = ForEach(&root, func(index int, elem *yaml.Node) error { return nil }, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestForEachKey(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	hosts := map[string]string{}
+	order := []string{}
+	err = ForEachKey(&root, func(key string, value *yaml.Node) error {
+		host, err := GetValue[string](value, "host")
+		if err != nil {
+			return err
+		}
+		hosts[key] = *host
+		order = append(order, key)
+		return nil
+	}, "servers")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"server1": "server1.local", "server2": "server2.local"}, hosts)
+	require.Equal(t, []string{"server1", "server2"}, order)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = ForEachKey(&root, func(key string, value *yaml.Node) error {
+		calls++
+		return errStop
+	})
+	require.Equal(t, errStop, err)
+	require.Equal(t, 1, calls)
+
+	err = ForEachKey(&root, func(key string, value *yaml.Node) error { return nil }, "clients")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+}
+
+func TestGetAll(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+"  - 40\n"), &root)
+	require.NoError(t, err)
+
+	matchPaths := func(matches []Match) [][]string {
+		paths := [][]string{}
+		for _, match := range matches {
+			paths = append(paths, match.Path)
+		}
+		return paths
+	}
+
+	matches, err := GetAll(&root, "servers", "{*}", "host")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"servers", "server1", "host"}, {"servers", "server2", "host"}}, matchPaths(matches))
+	require.Equal(t, "server1.local", matches[0].Node.Value)
+	require.Equal(t, "server2.local", matches[1].Node.Value)
+
+	matches, err = GetAll(&root, "clients", "[*]", "{*}")
+	require.NoError(t, err)
+	require.Len(t, matches, 4)
+	require.Equal(t, []string{"clients", "[1]", "name"}, matches[2].Path)
+	require.Equal(t, "second_client", matches[2].Node.Value)
+
+	matches, err = GetAll(&root, "{*}", "[*]")
+	require.NoError(t, err)
+	require.Len(t, matches, 6)
+	require.Equal(t, []string{"ints", "[3]"}, matches[5].Path)
+
+	matches, err = GetAll(&root, "ints", "[1:3]")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"ints", "[1]"}, {"ints", "[2]"}}, matchPaths(matches))
+
+	// branches without rest of the path are skipped
+	matches, err = GetAll(&root, "{*}", "server1", "port")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"servers", "server1", "port"}}, matchPaths(matches))
+
+	matches, err = GetAll(&root, "{*}", "unknown")
+	require.NoError(t, err)
+	require.Empty(t, matches)
+
+	matches, err = GetAll(&root, "servers", "server1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"servers", "server1"}}, matchPaths(matches))
+
+	_, err = GetAll(&root, "unknown", "{*}")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = GetAll(&root, "ints", "{*}")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	_, err = GetAll(nil, "ints")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestSelect(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML+`services:
+  - name: web
+    port: 80
+    enabled: true
+  - name: db
+    port: 5432
+    enabled: false
+  - name: cache
+    port: 6379
+`), &root)
+	require.NoError(t, err)
+
+	values := func(nodes []*yaml.Node) []string {
+		result := []string{}
+		for _, node := range nodes {
+			result = append(result, node.Value)
+		}
+		return result
+	}
+
+	nodes, err := Select(&root, `clients[?name == "first_client"].surname`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first_surname"}, values(nodes))
+
+	nodes, err = Select(&root, `clients[?name != 'first_client'].surname`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"second_surname"}, values(nodes))
+
+	nodes, err = Select(&root, "services[?enabled == true].name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, values(nodes))
+
+	// items without the field never match
+	nodes, err = Select(&root, "services[?enabled != true].name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"db"}, values(nodes))
+
+	nodes, err = Select(&root, "services[?port==5432]")
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, yaml.MappingNode, nodes[0].Kind)
+
+	nodes, err = Select(&root, "services[?name == unknown].port")
+	require.NoError(t, err)
+	require.Empty(t, nodes)
+
+	nodes, err = Select(&root, "services[?port == 80].missing")
+	require.NoError(t, err)
+	require.Empty(t, nodes)
+
+	nodes, err = Select(&root, "servers.{*}.host")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1.local", "server2.local"}, values(nodes))
+
+	matches, err := GetAll(&root, "services", "[?port == 6379]", "name")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, []string{"services", "[2]", "name"}, matches[0].Path)
+	require.Equal(t, "cache", matches[0].Node.Value)
+
+	_, err = Select(&root, "services[?port]")
+	require.ErrorIs(t, err, ErrInvalidPath)
+	require.EqualError(t, err, "invalid path format: filter [?port], expected [?field == value] or [?field != value] at services.[?port]")
+
+	_, err = Select(&root, "servers[?name == x]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	_, err = Select(&root, "unknown[?name == x]")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = Select(&root, "services[?name == x")
+	require.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Select(&root, `services[?name == "x]`)
+	require.ErrorIs(t, err, ErrInvalidPath)
+
+	// operators and brackets inside quoted values are part of the value
+	err = yaml.Unmarshal([]byte(`items:
+  - {name: "a!=b", id: 1}
+  - {name: "a==b", id: 2}
+  - {name: "[x]", id: 3}
+  - {name: a, id: 4}
+`), &root)
+	require.NoError(t, err)
+
+	nodes, err = Select(&root, `items[?name == "a!=b"].id`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, values(nodes))
+
+	nodes, err = Select(&root, `items[?name != 'a==b'].id`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3", "4"}, values(nodes))
+
+	nodes, err = Select(&root, `items[?name == "[x]"].id`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3"}, values(nodes))
+
+	_, err = Select(nil, "services")
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestCount(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	highPort := func(node *yaml.Node) bool {
+		port, err := GetValueAtNode[int](node, "port")
+		return err == nil && *port > 9001
+	}
+
+	count, err := Count(&root, highPort, "servers")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	count, err = Count(&root, func(node *yaml.Node) bool { return node.Value >= "20" }, "ints")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	count, err = Count(&root, func(node *yaml.Node) bool { return true })
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	_, err = Count(&root, highPort, "ints", "[0]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+	require.EqualError(t, err, "unexpected node kind provided at ints.[0] (line 15, col 5)")
+
+	_, err = Count(&root, highPort, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
@@ -0,0 +1,42 @@
+package gyml
+
+import (
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SafeNode guards yaml tree for concurrent use, reads take read lock and modifications take write lock
+// The wrapped root must not be accessed directly while SafeNode is in use
+type SafeNode struct {
+	sync.RWMutex
+	root *yaml.Node
+}
+
+// NewSafeNode wraps root for concurrent use
+// Examples:
+// config := NewSafeNode(&root); config.Get(&port, "servers", "server1", "port") - safe to call from several goroutines
+func NewSafeNode(root *yaml.Node) *SafeNode {
+	return &SafeNode{root: root}
+}
+
+// Get decodes value on the path into out, which has to be a pointer, it works as GetValue
+func (s *SafeNode) Get(out any, keys ...string) error {
+	s.RLock()
+	defer s.RUnlock()
+	return defaultNavigator.Get(s.root, out, keys...)
+}
+
+// Set works as SetValue
+func (s *SafeNode) Set(data any, keys ...string) error {
+	s.Lock()
+	defer s.Unlock()
+	return defaultNavigator.Set(s.root, data, keys...)
+}
+
+// Delete works as DeleteValue
+func (s *SafeNode) Delete(keys ...string) error {
+	s.Lock()
+	defer s.Unlock()
+	return defaultNavigator.Delete(s.root, keys...)
+}
@@ -0,0 +1,54 @@
+package gyml
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeNode(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	config := NewSafeNode(&root)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, config.Set(9100+i, "servers", fmt.Sprintf("server%d", i+10), "port"))
+			require.NoError(t, config.Set(i, "ints", "[]"))
+		}()
+		go func() {
+			defer wg.Done()
+			var host string
+			require.NoError(t, config.Get(&host, "servers", "server1", "host"))
+			require.Equal(t, "server1.local", host)
+		}()
+	}
+	wg.Wait()
+
+	var servers map[string]any
+	err = config.Get(&servers, "servers")
+	require.NoError(t, err)
+	require.Len(t, servers, 12)
+
+	var ints []int
+	err = config.Get(&ints, "ints")
+	require.NoError(t, err)
+	require.Len(t, ints, 13)
+
+	err = config.Delete("servers", "server10")
+	require.NoError(t, err)
+
+	var port int
+	err = config.Get(&port, "servers", "server10", "port")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
@@ -0,0 +1,69 @@
+package gyml
+
+import (
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SortKeys sorts key/value pairs of mapping node on the path alphabetically by key,
+// every key stays with its value and comments, the sort is not recursive
+// Examples:
+// SortKeys(&root, "servers") - servers in alphabetical order
+func SortKeys(root *yaml.Node, keys ...string) error {
+	node, err := resolveWritableNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	sortMappingKeys(node)
+	return nil
+}
+
+// sortMappingKeys sorts key/value pairs of mapping node by key, pairs with equal keys keep their order
+func sortMappingKeys(node *yaml.Node) {
+	pairs := make([][]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i < len(node.Content); i += 2 {
+		pairs = append(pairs, node.Content[i:i+2])
+	}
+
+	slices.SortStableFunc(pairs, func(a, b []*yaml.Node) int {
+		return strings.Compare(a[0].Value, b[0].Value)
+	})
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, pair := range pairs {
+		content = append(content, pair...)
+	}
+	node.Content = content
+}
+
+// SortSequence sorts items of sequence node on the path by less comparator, items equal by less keep their order
+// Examples:
+// SortSequence(&root, func(a, b *yaml.Node) bool { return a.Value < b.Value }, "allowed_hosts")
+func SortSequence(root *yaml.Node, less func(a, b *yaml.Node) bool, keys ...string) error {
+	node, err := resolveWritableNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	slices.SortStableFunc(node.Content, func(a, b *yaml.Node) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
+	return nil
+}
@@ -0,0 +1,73 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortKeys(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte("# zeta comment\nzeta: 1\nalpha:\n  b: 2\n  a: 1\nmid: 3 # mid comment\n"), &root)
+	require.NoError(t, err)
+
+	err = SortKeys(&root)
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, "alpha:\n  b: 2\n  a: 1\nmid: 3 # mid comment\n# zeta comment\nzeta: 1\n", string(out))
+
+	err = SortKeys(&root, "alpha")
+	require.NoError(t, err)
+
+	keys, err := Keys(&root, "alpha")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, keys)
+
+	value, err := GetValue[int](&root, "alpha", "b")
+	require.NoError(t, err)
+	require.Equal(t, 2, *value)
+
+	err = SortKeys(&root, "mid")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = SortKeys(&root, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestSortSequence(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	byName := func(a, b *yaml.Node) bool {
+		nameA, _ := GetValueAtNode[string](a, "name")
+		nameB, _ := GetValueAtNode[string](b, "name")
+		return *nameA > *nameB
+	}
+
+	err = SortSequence(&root, byName, "clients")
+	require.NoError(t, err)
+
+	names, err := GetValue[[]string](&root, "clients", "[*]", "name")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second_client", "first_client"}, *names)
+
+	err = SetValue(&root, 5, "ints", "[]")
+	require.NoError(t, err)
+
+	err = SortSequence(&root, func(a, b *yaml.Node) bool { return len(a.Value) < len(b.Value) }, "ints")
+	require.NoError(t, err)
+
+	ints, err := GetValue[[]int](&root, "ints")
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 10, 20, 30}, *ints)
+
+	err = SortSequence(&root, byName, "servers")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+}
@@ -0,0 +1,40 @@
+package gyml
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// SetFlowStyle switches mapping or sequence node on the path to flow style, e.g. {a: 1, b: 2} or [1, 2]
+// yaml.v3 emits everything nested in flow node in flow style as well, recursive additionally sets the style
+// on all nested mappings and sequences, so they stay compact even when moved out of the node later
+// Examples:
+// SetFlowStyle(&root, false, "ints") - ints: [10, 20, 30]
+// SetFlowStyle(&root, true, "servers") - servers: {server1: {host: server1.local, port: 9001}, ...}
+func SetFlowStyle(root *yaml.Node, recursive bool, keys ...string) error {
+	node, err := resolveWritableNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return newPathError(ErrUnexpectedNodeKind, keys)
+	}
+
+	setFlowStyle(node, recursive)
+	return nil
+}
+
+func setFlowStyle(node *yaml.Node, recursive bool) {
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return
+	}
+
+	node.Style |= yaml.FlowStyle
+	if !recursive {
+		return
+	}
+
+	for _, child := range node.Content {
+		setFlowStyle(child, recursive)
+	}
+}
@@ -0,0 +1,62 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFlowStyle(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = SetFlowStyle(&root, false, "ints")
+	require.NoError(t, err)
+
+	err = SetFlowStyle(&root, false, "servers", "server1")
+	require.NoError(t, err)
+
+	out, err := Marshal(&root, Indent(2))
+	require.NoError(t, err)
+	require.Equal(t, `clients:
+  - name: first_client
+    surname: first_surname
+  - name: second_client
+    surname: second_surname
+servers:
+  server1: {host: server1.local, port: 9001}
+  server2:
+    host: server2.local
+    port: 9002
+ints: [10, 20, 30]
+`, string(out))
+
+	err = SetFlowStyle(&root, true, "clients")
+	require.NoError(t, err)
+
+	node, err := resolveNode(&root, "clients", "[1]")
+	require.NoError(t, err)
+	require.Equal(t, yaml.FlowStyle, node.Style)
+
+	node, err = resolveNode(&root, "servers", "server2")
+	require.NoError(t, err)
+	require.Equal(t, yaml.Style(0), node.Style)
+
+	// nested node keeps flow style after it is moved out of flow parent
+	err = MoveValue(&root, []string{"clients", "[1]"}, []string{"last_client"})
+	require.NoError(t, err)
+
+	out, err = GetValueBytes(&root, "last_client")
+	require.NoError(t, err)
+	require.Equal(t, "{name: second_client, surname: second_surname}\n", string(out))
+
+	err = SetFlowStyle(&root, false, "ints", "[0]")
+	require.ErrorIs(t, err, ErrUnexpectedNodeKind)
+
+	err = SetFlowStyle(&root, false, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
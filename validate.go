@@ -0,0 +1,194 @@
+package gyml
+
+import (
+	"cmp"
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	unmarshalerType   = reflect.TypeFor[yaml.Unmarshaler]()
+	marshalerType     = reflect.TypeFor[yaml.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	nodeType          = reflect.TypeFor[yaml.Node]()
+)
+
+// ValidateAgainst checks that node on the path has shape of DataType before it is decoded,
+// mapping key without corresponding struct field returns ErrUnknownKey (as yaml.Decoder.KnownFields(true) does)
+// and missing key of struct field without omitempty option returns ErrKeyNotFound, both with path of the key
+// Nested structs, slices and maps of structs are checked recursively, types implementing yaml.Unmarshaler are not checked
+// Finally the node is decoded into DataType, so type mismatches are reported as well
+// Examples:
+// ValidateAgainst[Config](&root) - "unknown key at servers.server1.hots (line 9, col 5)" for typo in host key
+func ValidateAgainst[DataType any](root *yaml.Node, keys ...string) error {
+	node, err := resolveNode(root, keys...)
+	if err != nil {
+		return err
+	}
+
+	if err := validateNode(node, reflect.TypeFor[DataType](), slices.Clone(keys)); err != nil {
+		return err
+	}
+
+	var value DataType
+	return decodeNode(node, &value)
+}
+
+// structField is mapping key of struct field as yaml.v3 encodes it
+type structField struct {
+	name     string
+	goName   string
+	tagged   bool
+	index    []int
+	typ      reflect.Type
+	required bool
+}
+
+// structFields returns fields of struct type, inline structs are flattened, inlineMap is index of inline map
+// accepting any key or nil when struct has no such map
+func structFields(t reflect.Type) (fields []structField, inlineMap []int) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		flags := strings.Split(options, ",")
+
+		if slices.Contains(flags, "inline") {
+			inlineType := field.Type
+			if inlineType.Kind() == reflect.Pointer {
+				inlineType = inlineType.Elem()
+			}
+			switch inlineType.Kind() {
+			case reflect.Struct:
+				inlineFields, inlineFieldsMap := structFields(inlineType)
+				for _, inlineField := range inlineFields {
+					inlineField.index = append([]int{i}, inlineField.index...)
+					fields = append(fields, inlineField)
+				}
+				if inlineFieldsMap != nil {
+					inlineMap = append([]int{i}, inlineFieldsMap...)
+				}
+			case reflect.Map:
+				inlineMap = []int{i}
+			}
+			continue
+		}
+
+		fields = append(fields, structField{
+			name:     cmp.Or(name, strings.ToLower(field.Name)),
+			goName:   field.Name,
+			tagged:   name != "",
+			index:    []int{i},
+			typ:      field.Type,
+			required: !slices.Contains(flags, "omitempty"),
+		})
+	}
+	return fields, inlineMap
+}
+
+func validateNode(node *yaml.Node, t reflect.Type, path []string) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nodeType || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields, inlineMap := structFields(t)
+		entries := mappingEntries(node)
+
+		for _, entry := range entries {
+			i := slices.IndexFunc(fields, func(field structField) bool { return field.name == entry[0].Value })
+			keyPath := appendPath(path, escapeKey(entry[0].Value))
+			if i < 0 {
+				if inlineMap != nil {
+					continue
+				}
+				return newNodeError(ErrUnknownKey, keyPath, entry[0])
+			}
+			if err := validateNode(entry[1], fields[i].typ, keyPath); err != nil {
+				return err
+			}
+		}
+
+		for _, field := range fields {
+			present := slices.ContainsFunc(entries, func(entry [2]*yaml.Node) bool { return entry[0].Value == field.name })
+			if field.required && !present {
+				return newNodeError(ErrKeyNotFound, appendPath(path, escapeKey(field.name)), node)
+			}
+		}
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			if err := validateNode(item, t.Elem(), appendPath(path, fmt.Sprintf("[%d]", i))); err != nil {
+				return err
+			}
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for _, entry := range mappingEntries(node) {
+			if err := validateNode(entry[1], t.Elem(), appendPath(path, escapeKey(entry[0].Value))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mappingEntries returns key/value pairs of mapping node with "<<" merge keys expanded,
+// keys of the mapping itself override merged ones as in decoding
+func mappingEntries(node *yaml.Node) [][2]*yaml.Node {
+	entries := [][2]*yaml.Node{}
+	add := func(key, value *yaml.Node) {
+		i := slices.IndexFunc(entries, func(entry [2]*yaml.Node) bool { return entry[0].Value == key.Value })
+		if i < 0 {
+			entries = append(entries, [2]*yaml.Node{key, value})
+		}
+	}
+
+	var merged []*yaml.Node
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == "<<" && node.Content[i].ShortTag() == "!!merge" {
+			merged = append(merged, node.Content[i+1])
+			continue
+		}
+		add(node.Content[i], node.Content[i+1])
+	}
+
+	for _, source := range merged {
+		if source.Kind == yaml.AliasNode {
+			source = source.Alias
+		}
+		sources := []*yaml.Node{source}
+		if source.Kind == yaml.SequenceNode {
+			sources = source.Content
+		}
+		for _, mapping := range sources {
+			if mapping.Kind == yaml.AliasNode {
+				mapping = mapping.Alias
+			}
+			if mapping.Kind == yaml.MappingNode {
+				for _, entry := range mappingEntries(mapping) {
+					add(entry[0], entry[1])
+				}
+			}
+		}
+	}
+	return entries
+}
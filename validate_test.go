@@ -0,0 +1,115 @@
+package gyml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+type validateServer struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	User string `yaml:"user,omitempty"`
+}
+
+type validateClient struct {
+	Name    string `yaml:"name"`
+	Surname string `yaml:"surname"`
+}
+
+type validateConfig struct {
+	Clients []validateClient          `yaml:"clients"`
+	Servers map[string]validateServer `yaml:"servers"`
+	Ints    []int                     `yaml:"ints"`
+}
+
+func TestValidateAgainst(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	err = ValidateAgainst[validateConfig](&root)
+	require.NoError(t, err)
+
+	err = ValidateAgainst[validateServer](&root, "servers", "server1")
+	require.NoError(t, err)
+
+	err = RenameKey(&root, "hots", "servers", "server2", "host")
+	require.NoError(t, err)
+
+	err = ValidateAgainst[validateConfig](&root)
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.EqualError(t, err, "unknown key at servers.server2.hots (line 12, col 5)")
+
+	err = ValidateAgainst[validateServer](&root, "servers", "server2")
+	require.EqualError(t, err, "unknown key at servers.server2.hots (line 12, col 5)")
+
+	err = RenameKey(&root, "host", "servers", "server2", "hots")
+	require.NoError(t, err)
+
+	err = DeleteValue(&root, "clients", "[1]", "surname")
+	require.NoError(t, err)
+
+	err = ValidateAgainst[validateConfig](&root)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.EqualError(t, err, "key not found at clients.[1].surname (line 5, col 5)")
+
+	err = SetValue(&root, "second_surname", "clients", "[1]", "surname")
+	require.NoError(t, err)
+
+	err = SetValue(&root, "x", "ints", "[0]")
+	require.NoError(t, err)
+
+	// type mismatch is reported by decoding
+	err = ValidateAgainst[validateConfig](&root)
+	require.ErrorContains(t, err, "cannot decode yaml node value")
+
+	err = ValidateAgainst[validateConfig](&root, "unknown")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	err = ValidateAgainst[validateConfig](nil)
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestValidateAgainstInlineAndMerge(t *testing.T) {
+	type base struct {
+		Host string `yaml:"host"`
+	}
+	type server struct {
+		base  `yaml:",inline"`
+		Port  int            `yaml:"port"`
+		Extra map[string]any `yaml:",inline"`
+	}
+	type strictServer struct {
+		base `yaml:",inline"`
+		Port int
+	}
+
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(`defaults: &defaults
+  host: base.local
+servers:
+  - <<: *defaults
+    port: 9001
+  - host: other.local
+    port: 9002
+    region: eu
+`), &root)
+	require.NoError(t, err)
+
+	err = ValidateAgainst[[]server](&root, "servers")
+	require.NoError(t, err)
+
+	err = ValidateAgainst[strictServer](&root, "servers", "[0]")
+	require.NoError(t, err)
+
+	err = ValidateAgainst[[]strictServer](&root, "servers")
+	require.EqualError(t, err, "unknown key at servers.[1].region (line 8, col 5)")
+
+	err = ValidateAgainst[base](&root, "servers", "[0]")
+	require.EqualError(t, err, "unknown key at servers.[0].port (line 5, col 5)")
+}
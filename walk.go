@@ -0,0 +1,92 @@
+package gyml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Walk visits every node of the tree depth first in document order and calls fn with its path and the node
+// Path uses the same keys as GetValue ("[i]" for sequence items, key for mapping values), document node is not visited
+// When fn returns ErrSkipSubtree children of the node are not visited, any other error stops the walk and is returned
+// Alias nodes are visited but not followed
+// Examples:
+// Walk(&root, redactPasswords) - call redactPasswords for every node of the document
+func Walk(root *yaml.Node, fn func(path []string, node *yaml.Node) error) error {
+	if root == nil {
+		return ErrRootNodeNotSet
+	}
+
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+		root = root.Content[0]
+	}
+
+	if root.Kind == 0 {
+		return nil
+	}
+
+	err := walkNode(root, []string{}, fn)
+	if errors.Is(err, ErrSkipSubtree) {
+		return nil
+	}
+	return err
+}
+
+// walkCheckInterval is number of visited nodes after which WalkCtx checks the context
+const walkCheckInterval = 1000
+
+// WalkCtx works as Walk, but context is checked periodically and the walk is stopped with ctx.Err() when it is done
+// Examples:
+// WalkCtx(r.Context(), &root, redactPasswords) - stop redacting when the request deadline is exceeded
+func WalkCtx(ctx context.Context, root *yaml.Node, fn func(path []string, node *yaml.Node) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	visited := 0
+	return Walk(root, func(path []string, node *yaml.Node) error {
+		visited++
+		if visited%walkCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		return fn(path, node)
+	})
+}
+
+func walkNode(node *yaml.Node, path []string, fn func(path []string, node *yaml.Node) error) error {
+	if err := fn(path, node); err != nil {
+		return err
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if err := walkChild(item, appendPath(path, fmt.Sprintf("[%d]", i)), fn); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			if err := walkChild(node.Content[i+1], appendPath(path, escapeKey(node.Content[i].Value)), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkChild walks child subtree, ErrSkipSubtree of the child does not stop walking of its siblings
+func walkChild(node *yaml.Node, path []string, fn func(path []string, node *yaml.Node) error) error {
+	err := walkNode(node, path, fn)
+	if errors.Is(err, ErrSkipSubtree) {
+		return nil
+	}
+	return err
+}
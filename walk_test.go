@@ -0,0 +1,131 @@
+package gyml
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	paths := []string{}
+	err = Walk(&root, func(path []string, node *yaml.Node) error {
+		paths = append(paths, strings.Join(path, "."))
+		if len(path) == 1 && path[0] == "servers" {
+			return ErrSkipSubtree
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"",
+		"clients",
+		"clients.[0]",
+		"clients.[0].name",
+		"clients.[0].surname",
+		"clients.[1]",
+		"clients.[1].name",
+		"clients.[1].surname",
+		"servers",
+		"ints",
+		"ints.[0]",
+		"ints.[1]",
+		"ints.[2]",
+	}, paths)
+
+	// redact all hosts
+	err = Walk(&root, func(path []string, node *yaml.Node) error {
+		if len(path) > 0 && path[len(path)-1] == "host" {
+			node.Value = "redacted"
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	hosts, err := GetValue[string](&root, "servers", "server2", "host")
+	require.NoError(t, err)
+	require.Equal(t, "redacted", *hosts)
+
+	errStop := errors.New("stop")
+	visited := 0
+	err = Walk(&root, func(path []string, node *yaml.Node) error {
+		visited++
+		if visited == 3 {
+			return errStop
+		}
+		return nil
+	})
+	require.Equal(t, errStop, err)
+	require.Equal(t, 3, visited)
+
+	err = Walk(&root, func(path []string, node *yaml.Node) error {
+		return ErrSkipSubtree
+	})
+	require.NoError(t, err)
+
+	var rootEmpty yaml.Node
+	err = Walk(&rootEmpty, func(path []string, node *yaml.Node) error {
+		return errStop
+	})
+	require.NoError(t, err)
+
+	err = Walk(nil, func(path []string, node *yaml.Node) error { return nil })
+	require.Equal(t, ErrRootNodeNotSet, err)
+}
+
+func TestWalkCtx(t *testing.T) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal([]byte(testYAML), &root)
+	require.NoError(t, err)
+
+	visited := 0
+	err = WalkCtx(context.Background(), &root, func(path []string, node *yaml.Node) error {
+		visited++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 19, visited)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	visited = 0
+	err = WalkCtx(ctx, &root, func(path []string, node *yaml.Node) error {
+		visited++
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, visited)
+
+	// cancellation during the walk is noticed on the next check
+	items := make([]any, 3*walkCheckInterval)
+	err = SetValue(&root, items, "large")
+	require.NoError(t, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	visited = 0
+	err = WalkCtx(ctx, &root, func(path []string, node *yaml.Node) error {
+		visited++
+		if visited == 10 {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, walkCheckInterval-1, visited)
+
+	err = WalkCtx(context.Background(), nil, func(path []string, node *yaml.Node) error { return nil })
+	require.Equal(t, ErrRootNodeNotSet, err)
+}